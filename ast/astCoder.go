@@ -1,8 +1,10 @@
 package ast
 
 import (
+	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"strings"
 
 	"github.com/liasece/gocoder"
@@ -12,6 +14,8 @@ type CodeDecoder struct {
 	fset         *token.FileSet
 	pkgs         *Packages
 	DecodedTypes map[string]*LoadedType
+	uses         map[*ast.Ident]types.Object // the checked identifiers, only in the packages loader mode
+	registry     TypeRegistry
 }
 
 type LoadedType struct {
@@ -38,9 +42,34 @@ func NewCodeDecoder(paths ...string) (*CodeDecoder, error) {
 		fset:         fset,
 		pkgs:         ps,
 		DecodedTypes: make(map[string]*LoadedType),
+		uses:         nil,
+		registry:     DefaultTypeRegistry.Clone(),
 	}, nil
 }
 
+// TypeRegistry the registry of known types, default is a copy of DefaultTypeRegistry at the decoder creation
+func (c *CodeDecoder) TypeRegistry() TypeRegistry {
+	return c.registry
+}
+
+// SetTypeRegistry func, like `c.SetTypeRegistry(DefaultTypeRegistry)` to share the global registry
+func (c *CodeDecoder) SetTypeRegistry(registry TypeRegistry) {
+	c.registry = registry
+}
+
+// RegisterType func, like `c.RegisterType("github.com/google/uuid.UUID", uuid.UUID{})`, only for this decoder
+func (c *CodeDecoder) RegisterType(fullName string, typ interface{}) {
+	c.registry.Register(fullName, typ)
+}
+
+// TypeStringToZeroInterface the predeclared types and the types of registry
+func (c *CodeDecoder) TypeStringToZeroInterface(str string) gocoder.Type {
+	if res := basicTypeStringToZeroInterface(str); res != nil {
+		return res
+	}
+	return c.registry.Lookup(str)
+}
+
 func GetTypeFromSource(path string, typeName string) (gocoder.Type, error) {
 	c, err := NewCodeDecoder(path)
 	if err != nil {
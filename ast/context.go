@@ -3,18 +3,24 @@ package ast
 import (
 	"go/ast"
 	"strings"
+
+	"github.com/liasece/gocoder"
 )
 
 type DecoderContext interface {
 	GetPkgByAlias(alias string) string
 	GetBuildingItemName() string
 	GetCurrentPkg() string
+	GetTypeParam(name string) gocoder.Type
+	WithTypeParams(names ...string) DecoderContext
+	WithBuildingItemName(name string) DecoderContext
 }
 
 type decoderContext struct {
 	currentPkg       string
 	buildingItemName string
 	pkgAliasMap      map[string]string // key: imported package alias, value: imported package full path
+	typeParams       []string          // type parameter names in scope, like `T` in `type List[T any] struct{}`
 }
 
 func NewDecoderContextByAstFile(currentPkg string, buildingItemName string, astFile *ast.File) *decoderContext {
@@ -37,6 +43,7 @@ func NewDecoderContextByAstFile(currentPkg string, buildingItemName string, astF
 		currentPkg:       currentPkg,
 		buildingItemName: buildingItemName,
 		pkgAliasMap:      pkgAliasMap,
+		typeParams:       nil,
 	}
 	return res
 }
@@ -59,3 +66,42 @@ func (c *decoderContext) GetBuildingItemName() string {
 func (c *decoderContext) GetCurrentPkg() string {
 	return c.currentPkg
 }
+
+func (c *decoderContext) GetTypeParam(name string) gocoder.Type {
+	for _, v := range c.typeParams {
+		if v == name {
+			return gocoder.NewTypeName(name)
+		}
+	}
+	return nil
+}
+
+// WithTypeParams returns a copy of the context which can resolve the type parameters
+func (c *decoderContext) WithTypeParams(names ...string) DecoderContext {
+	if len(names) == 0 {
+		return c
+	}
+	typeParams := make([]string, 0, len(c.typeParams)+len(names))
+	typeParams = append(typeParams, c.typeParams...)
+	typeParams = append(typeParams, names...)
+	return &decoderContext{
+		currentPkg:       c.currentPkg,
+		buildingItemName: c.buildingItemName,
+		pkgAliasMap:      c.pkgAliasMap,
+		typeParams:       typeParams,
+	}
+}
+
+// WithBuildingItemName returns a copy of the context which is building another item, like the anonymous
+// `struct{}` in a func param, which must not be named by the declaring type
+func (c *decoderContext) WithBuildingItemName(name string) DecoderContext {
+	if name == c.buildingItemName {
+		return c
+	}
+	return &decoderContext{
+		currentPkg:       c.currentPkg,
+		buildingItemName: name,
+		pkgAliasMap:      c.pkgAliasMap,
+		typeParams:       c.typeParams,
+	}
+}
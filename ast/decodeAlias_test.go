@@ -0,0 +1,56 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func TestGetAliasTypeFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/alias.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		alias      bool
+		underlying reflect.Kind
+		want       string
+	}{
+		{name: "Status", alias: false, underlying: reflect.String, want: "// Status of the order\ntype Status string\n"},
+		{name: "Deadline", alias: true, underlying: reflect.Struct, want: "// Deadline is an alias of time.Time\ntype Deadline = time.Time\n"},
+		{name: "OrderAlias", alias: true, underlying: reflect.Struct, want: "type OrderAlias = Order\n"},
+		{name: "Orders", alias: false, underlying: reflect.Slice, want: "type Orders []Order\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := c.GetType(tt.name)
+			if typ == nil {
+				t.Fatalf("GetType(%s) not found", tt.name)
+			}
+			if typ.IsAlias() != tt.alias {
+				t.Errorf("IsAlias() = %v, want %v", typ.IsAlias(), tt.alias)
+			}
+			if got := typ.Underlying().Kind(); got != tt.underlying {
+				t.Errorf("Underlying().Kind() = %v, want %v", got, tt.underlying)
+			}
+			if got := gocoder.ToCode(typ, gocoder.NewToCodeOpt().PkgPath("github.com/liasece/gocoder/test/source/testdata")); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := c.GetType("OrderAlias").Unalias().GetNamed(); got != "Order" {
+		t.Errorf("Unalias().GetNamed() = %q, want Order", got)
+	}
+	if got := c.GetType("Status").Unalias().GetNamed(); got != "Status" {
+		t.Errorf("defined type Unalias().GetNamed() = %q, want Status", got)
+	}
+	item := c.GetType("Item")
+	for _, name := range []string{"Status", "Deadline", "Orders"} {
+		if got := typeRefCode(item.FieldByName(name).GetType()); got != name {
+			t.Errorf("field %s type = %q, want %q", name, got, name)
+		}
+	}
+}
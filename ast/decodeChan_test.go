@@ -0,0 +1,41 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetChanTypeFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/chan.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := c.GetType("Pool")
+	if typ == nil {
+		t.Fatal("GetType(Pool) not found")
+	}
+	tests := []struct {
+		field   string
+		wantDir reflect.ChanDir
+		want    string
+	}{
+		{field: "Jobs", wantDir: reflect.BothDir, want: "chan *Job"},
+		{field: "Results", wantDir: reflect.RecvDir, want: "<-chan int"},
+		{field: "Done", wantDir: reflect.SendDir, want: "chan<- bool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			f := typ.FieldByName(tt.field)
+			if f == nil {
+				t.Fatalf("FieldByName(%s) not found", tt.field)
+			}
+			ft := f.GetType()
+			if !ft.IsChan() || ft.GetChanDir() != tt.wantDir {
+				t.Errorf("IsChan() = %v, GetChanDir() = %v, want %v", ft.IsChan(), ft.GetChanDir(), tt.wantDir)
+			}
+			if got := ft.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func fieldNames(fs []gocoder.Field) []string {
+	res := make([]string, len(fs))
+	for i, f := range fs {
+		res[i] = f.GetName()
+	}
+	return res
+}
+
+func TestGetEmbeddedFieldFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/embed.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := c.GetType("Doc")
+	if doc == nil {
+		t.Fatal("GetType(Doc) not found")
+	}
+	if got, want := fieldNames(doc.GetFields()), []string{"Base", "Audit", "Title", "Version"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFields() = %v, want %v", got, want)
+	}
+	if !doc.FieldByName("Base").IsEmbedded() || !doc.FieldByName("Audit").IsEmbedded() || doc.FieldByName("Title").IsEmbedded() {
+		t.Errorf("IsEmbedded() of Base, Audit, Title want true, true, false")
+	}
+	want := "type Doc struct {\nBase\n// Audit of the doc\n*Audit `json:\"audit\"`\nTitle string\nVersion int\n}\n"
+	if got := gocoder.ToCode(doc, gocoder.NewToCodeOpt().PkgPath("github.com/liasece/gocoder/test/source/testdata")); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+
+	page := c.GetType("Page")
+	if page == nil {
+		t.Fatal("GetType(Page) not found")
+	}
+	if got, want := fieldNames(page.FlattenFields()), []string{"Title", "ID", "CreatedAt", "By"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlattenFields() = %v, want %v", got, want)
+	}
+	if f := page.FieldByName("ID"); f == nil || f.GetType().Kind() != reflect.Int {
+		t.Errorf("FieldByName(ID) want the int field of Meta")
+	}
+	if f := page.FieldByName("Version"); f != nil {
+		t.Errorf("FieldByName(Version) = %v, want nil for ambiguous field", f.GetType().String())
+	}
+}
@@ -23,6 +23,7 @@ func (c *CodeDecoder) GetFuncsFromASTFuncDecl(ctx DecoderContext, st *ast.FuncDe
 		// get name
 		name = st.Name.Name
 	}
+	ctx = ctx.WithTypeParams(receiverTypeParamNames(st.Recv.List[0].Type)...)
 	receiver := c.GetReceiverFromASTField(ctx, st.Recv.List[0])
 	res := c.GetFuncsFromASTFuncType(ctx, receiver, name, st.Type)
 	if res != nil {
@@ -39,7 +40,12 @@ func (c *CodeDecoder) GetFuncFromASTField(ctx DecoderContext, receiver gocoder.R
 			name = st.Names[0].Name
 		}
 	}
-	res := c.GetFuncsFromASTFuncType(ctx, receiver, name, st.Type.(*ast.FuncType))
+	funcType, ok := st.Type.(*ast.FuncType)
+	if !ok {
+		// like the embedded `io.Reader` in interface
+		return nil
+	}
+	res := c.GetFuncsFromASTFuncType(ctx, receiver, name, funcType)
 	if res != nil {
 		res.AddNotes(c.GetNoteFromCommentGroup(ctx, st.Doc, st.Comment)...)
 	}
@@ -47,33 +53,65 @@ func (c *CodeDecoder) GetFuncFromASTField(ctx DecoderContext, receiver gocoder.R
 }
 
 func (c *CodeDecoder) GetFuncsFromASTFuncType(ctx DecoderContext, receiver gocoder.Receiver, name string, se *ast.FuncType) gocoder.Func {
-	var args []gocoder.Arg
-	var returns []gocoder.Arg
+	typeParams, ctx := c.GetTypeParamsFromASTFieldList(ctx, se.TypeParams)
+	args := c.getArgsFromASTFieldList(ctx, se.Params)
+	returns := c.getArgsFromASTFieldList(ctx, se.Results)
+
+	res := gocoder.NewFunc(gocoder.FuncTypeDefault, name, receiver, args, returns)
+	res.SetTypeParams(typeParams)
+	return res
+}
 
-	if se.Params != nil {
-		for _, arg := range se.Params.List {
-			argType := c.GetTypeFromASTNode(ctx, arg.Type)
-			fieldName := ""
-			for _, argName := range arg.Names {
-				fieldName = argName.Name
+// getArgsFromASTFieldList decode the params or results of func, like `a, b int`, `opts ...Option` or `(int, error)`
+func (c *CodeDecoder) getArgsFromASTFieldList(ctx DecoderContext, st *ast.FieldList) []gocoder.Arg {
+	if st == nil {
+		return nil
+	}
+	var res []gocoder.Arg
+	// the types in signature are anonymous, like `interface{}` in `args ...interface{}`
+	ctx = ctx.WithBuildingItemName("")
+	for _, field := range st.List {
+		typeExpr := field.Type
+		variadic := false
+		if ellipsis, ok := typeExpr.(*ast.Ellipsis); ok {
+			typeExpr = ellipsis.Elt
+			variadic = true
+		}
+		argType := c.GetTypeFromASTNode(ctx, typeExpr)
+		names := []string{""}
+		if len(field.Names) > 0 {
+			names = make([]string, len(field.Names))
+			for i, v := range field.Names {
+				names[i] = v.Name
 			}
-			gocoderArg := gocoder.NewArg(fieldName, argType, false)
-			gocoderArg.AddNotes(c.GetNoteFromCommentGroup(ctx, arg.Doc, arg.Comment)...)
-			args = append(args, gocoderArg)
 		}
-	}
-	if se.Results != nil {
-		for _, arg := range se.Results.List {
-			argType := c.GetTypeFromASTNode(ctx, arg.Type)
-			fieldName := ""
-			for _, argName := range arg.Names {
-				fieldName = argName.Name
+		for i, name := range names {
+			typ := argType
+			if i > 0 && typ != nil {
+				typ = typ.Clone()
 			}
-			gocoderArg := gocoder.NewArg(fieldName, argType, false)
-			gocoderArg.AddNotes(c.GetNoteFromCommentGroup(ctx, arg.Doc, arg.Comment)...)
-			returns = append(returns, gocoderArg)
+			arg := gocoder.NewArg(name, typ, variadic)
+			if i == 0 {
+				arg.AddNotes(c.GetNoteFromCommentGroup(ctx, field.Doc, field.Comment)...)
+			}
+			res = append(res, arg)
 		}
 	}
+	return res
+}
 
-	return gocoder.NewFunc(gocoder.FuncTypeDefault, name, receiver, args, returns)
+// GetTypeFromASTFuncType decode func signature type, like `func(context.Context) error`
+func (c *CodeDecoder) GetTypeFromASTFuncType(ctx DecoderContext, st *ast.FuncType) gocoder.Type {
+	f := c.GetFuncsFromASTFuncType(ctx, nil, "", st)
+	for _, v := range f.GetArgs() {
+		if v.GetType() == nil {
+			return nil
+		}
+	}
+	for _, v := range f.GetReturns() {
+		if v.GetType() == nil {
+			return nil
+		}
+	}
+	return f.Signature()
 }
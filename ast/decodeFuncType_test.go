@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func typeRefCode(t gocoder.Type) string {
+	t = t.Clone()
+	t.SetInReference(true)
+	return gocoder.ToCode(t, gocoder.NewToCodeOpt().PkgPath("github.com/liasece/gocoder/test/source/testdata"))
+}
+
+func TestGetFuncTypeFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/callback.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus := c.GetType("Bus")
+	if bus == nil {
+		t.Fatal("GetType(Bus) not found")
+	}
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "OnEvent", want: "func(ctx context.Context, e *Event) (int, error)"},
+		{field: "OnClose", want: "func()"},
+		{field: "Handlers", want: "[]Handler"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			f := bus.FieldByName(tt.field)
+			if f == nil {
+				t.Fatalf("FieldByName(%s) not found", tt.field)
+			}
+			if got := typeRefCode(f.GetType()); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	handler := c.GetType("Handler")
+	if handler == nil {
+		t.Fatal("GetType(Handler) not found")
+	}
+	if got, want := typeRefCode(handler.Underlying()), "func(ctx context.Context, e *Event) error"; got != want {
+		t.Errorf("Handler underlying = %q, want %q", got, want)
+	}
+
+	methods := c.GetMethods("Bus")
+	if len(methods) != 1 {
+		t.Fatalf("GetMethods(Bus) = %d, want 1", len(methods))
+	}
+	sub := methods[0]
+	if got, want := typeRefCode(sub.GetArgs()[1].GetType()), "func(*Event) error"; got != want {
+		t.Errorf("arg type = %q, want %q", got, want)
+	}
+	if got, want := typeRefCode(sub.GetReturns()[0].GetType()), "func()"; got != want {
+		t.Errorf("return type = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestGetGenericTypeFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/generic.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name           string
+		typeName       string
+		wantTypeParams []string
+		wantFields     map[string]string
+	}{
+		{
+			name:           "single type param",
+			typeName:       "List",
+			wantTypeParams: []string{"T any"},
+			wantFields:     map[string]string{"Items": "[]T", "Len": "int"},
+		},
+		{
+			name:           "multiple type params",
+			typeName:       "Pair",
+			wantTypeParams: []string{"K comparable", "V any"},
+			wantFields:     map[string]string{"Key": "K", "Value": "V"},
+		},
+		{
+			name:           "instantiated fields",
+			typeName:       "Holder",
+			wantTypeParams: nil,
+			wantFields:     map[string]string{"Items": "List[Item]", "Pairs": "[]Pair[string, *Item]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := c.GetType(tt.typeName)
+			if typ == nil {
+				t.Fatalf("GetType(%s) not found", tt.typeName)
+			}
+			typeParams := typ.GetTypeParams()
+			if len(typeParams) != len(tt.wantTypeParams) {
+				t.Fatalf("GetTypeParams() = %v, want %v", typeParams, tt.wantTypeParams)
+			}
+			for i, v := range typeParams {
+				if got := v.GetName() + " " + v.GetType().String(); got != tt.wantTypeParams[i] {
+					t.Errorf("GetTypeParams()[%d] = %v, want %v", i, got, tt.wantTypeParams[i])
+				}
+			}
+			if len(typ.GetFields()) != len(tt.wantFields) {
+				t.Errorf("GetFields() len = %d, want %d", len(typ.GetFields()), len(tt.wantFields))
+			}
+			for name, want := range tt.wantFields {
+				f := typ.FieldByName(name)
+				if f == nil {
+					t.Errorf("FieldByName(%s) not found", name)
+					continue
+				}
+				if got := f.GetType().String(); got != want {
+					t.Errorf("FieldByName(%s) type = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetGenericMethodsFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/generic.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := c.GetMethods("List")
+	if len(methods) != 2 {
+		t.Fatalf("GetMethods(List) len = %d, want 2", len(methods))
+	}
+	if got := methods[0].GetReceiver().GetType().String(); got != "*List[T]" {
+		t.Errorf("receiver type = %v, want *List[T]", got)
+	}
+	if got := methods[1].GetReturnTypes()[0].String(); got != "T" {
+		t.Errorf("return type = %v, want T", got)
+	}
+
+	iface := c.GetInterface("Getter")
+	if iface == nil || len(iface.GetTypeParams()) != 1 {
+		t.Fatalf("GetInterface(Getter) type params = %v", iface)
+	}
+	if got := iface.FuncByName("Set").GetArgs()[0].GetType().String(); got != "T" {
+		t.Errorf("Getter.Set arg type = %v, want T", got)
+	}
+}
@@ -0,0 +1,83 @@
+package ast
+
+import (
+	"go/ast"
+	"reflect"
+	"sort"
+
+	"github.com/liasece/gocoder"
+)
+
+// Implementation a decoded type checked against an interface
+type Implementation struct {
+	Type       gocoder.Type
+	Ptr        bool     // only the pointer of type implements the interface, like `*Foo`
+	Missing    []string // the methods of interface not in the method set of type
+	Mismatched []string // the methods of interface with a different signature
+}
+
+// Implemented the type or the pointer of type implements the interface
+func (i *Implementation) Implemented() bool {
+	return len(i.Missing) == 0 && len(i.Mismatched) == 0
+}
+
+// FindImplementations scan the decoded packages for the types implement the interface,
+// the near-miss types, which have some methods of the interface, are reported with the missing and mismatched methods
+func (c *CodeDecoder) FindImplementations(iface gocoder.Type) []*Implementation {
+	var res []*Implementation
+	total := len(iface.MethodSet())
+	for _, pkgV := range c.pkgs.List {
+		// in the order of file names, the map order of files is random
+		fileNames := make([]string, 0, len(pkgV.Package.Files))
+		for name := range pkgV.Package.Files {
+			fileNames = append(fileNames, name)
+		}
+		sort.Strings(fileNames)
+		for _, fileName := range fileNames {
+			astFile := pkgV.Package.Files[fileName]
+			for _, astDecl := range astFile.Decls {
+				astGenDecl, ok := astDecl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+				for _, spec := range astGenDecl.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || ts.Assign.IsValid() {
+						continue
+					}
+					typ := c.GetType(pkgV.Name + "." + ts.Name.Name)
+					if typ == nil || typ.Kind() == reflect.Interface {
+						continue
+					}
+					if impl := checkImplementation(typ, iface, total); impl != nil {
+						res = append(res, impl)
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// checkImplementation check `T` first and then `*T`, nil if the type has none of the methods of interface
+func checkImplementation(typ gocoder.Type, iface gocoder.Type, total int) *Implementation {
+	missing, mismatched := gocoder.MissingMethods(typ, iface)
+	if len(missing) == 0 && len(mismatched) == 0 {
+		return &Implementation{
+			Type:       typ,
+			Ptr:        false,
+			Missing:    nil,
+			Mismatched: nil,
+		}
+	}
+	missing, mismatched = gocoder.MissingMethods(typ.TackPtr(), iface)
+	if len(missing) == total {
+		return nil
+	}
+	return &Implementation{
+		Type:       typ,
+		Ptr:        len(missing) == 0 && len(mismatched) == 0,
+		Missing:    missing,
+		Mismatched: mismatched,
+	}
+}
@@ -0,0 +1,42 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindImplementations(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/implement.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := c.GetType("Repo")
+	if repo == nil {
+		t.Fatal("GetType(Repo) not found")
+	}
+	if !c.GetType("MemRepo").Implements(repo) || c.GetType("DBRepo").Implements(repo) || !c.GetType("DBRepo").TackPtr().Implements(repo) {
+		t.Errorf("Implements() want MemRepo and *DBRepo")
+	}
+	type result struct {
+		Name       string
+		Ptr        bool
+		Missing    []string
+		Mismatched []string
+	}
+	got := make([]result, 0)
+	for _, v := range c.FindImplementations(repo) {
+		got = append(got, result{Name: v.Type.GetNamed(), Ptr: v.Ptr, Missing: v.Missing, Mismatched: v.Mismatched})
+		if v.Implemented() != (v.Missing == nil && v.Mismatched == nil) {
+			t.Errorf("%s Implemented() = %v", v.Type.GetNamed(), v.Implemented())
+		}
+	}
+	want := []result{
+		{Name: "MemRepo", Ptr: false, Missing: nil, Mismatched: nil},
+		{Name: "DBRepo", Ptr: true, Missing: nil, Mismatched: nil},
+		{Name: "BadRepo", Ptr: false, Missing: []string{"Put"}, Mismatched: []string{"Get"}},
+		{Name: "CachedRepo", Ptr: false, Missing: nil, Mismatched: nil},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindImplementations() = %+v, want %+v", got, want)
+	}
+}
@@ -11,10 +11,44 @@ import (
 
 func (c *CodeDecoder) GetInterfaceFromASTInterfaceType(ctx DecoderContext, st *ast.InterfaceType) gocoder.Type {
 	fs := c.GetFuncsFromASTFieldList(ctx, nil, st.Methods)
-	res := gocoder.NewInterface(ctx.GetBuildingItemName(), fs)
+	var embeds []gocoder.Type
+	for _, field := range st.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); ok {
+			continue
+		}
+		if typ := c.getInterfaceEmbedFromASTExpr(ctx, field.Type); typ != nil {
+			embeds = append(embeds, typ)
+		}
+	}
+	res := gocoder.NewInterfaceEmbeds(ctx.GetBuildingItemName(), embeds, fs)
 	return res
 }
 
+// getInterfaceEmbedFromASTExpr the embedded element of interface, like `io.Reader`, `Base` or `~int | ~string`
+func (c *CodeDecoder) getInterfaceEmbedFromASTExpr(ctx DecoderContext, expr ast.Expr) gocoder.Type {
+	if _, ok := expr.(*ast.BinaryExpr); ok {
+		terms := c.getTypeUnionTermsFromASTExpr(ctx, expr)
+		if terms == nil {
+			return nil
+		}
+		return gocoder.NewTypeUnion(terms...)
+	}
+	if typ := c.getTypeFromASTNodeWithName(ctx, expr); typ != nil {
+		return typ
+	}
+	// the interface not in the decoded packages, like `io.Reader`, keep it by name
+	switch t := expr.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			return gocoder.NewTypeDetail(ctx.GetPkgByAlias(x.Name), t.Sel.Name)
+		}
+	case *ast.Ident:
+		return gocoder.NewTypeName(t.Name)
+	}
+	log.Warn("getInterfaceEmbedFromASTExpr unknown embedded type", log.Any("name", ctx.GetBuildingItemName()), log.Any("type", reflect.TypeOf(expr)))
+	return nil
+}
+
 func (c *CodeDecoder) GetInterface(name string) gocoder.Type {
 	var resType gocoder.Type
 	typeTypeName := name
@@ -42,8 +76,10 @@ func (c *CodeDecoder) GetInterface(name string) gocoder.Type {
 					// found target type
 					if st, ok := ts.Type.(*ast.InterfaceType); ok {
 						ctx := NewDecoderContextByAstFile(pkgV.Name, typeTypeName, astFile)
-						resType = c.GetInterfaceFromASTInterfaceType(ctx, st)
+						typeParams, typeCtx := c.GetTypeParamsFromASTFieldList(ctx, ts.TypeParams)
+						resType = c.GetInterfaceFromASTInterfaceType(typeCtx, st)
 						if resType != nil {
+							resType.SetTypeParams(typeParams)
 							return false
 						}
 					} else {
@@ -0,0 +1,49 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func TestGetEmbeddedInterfaceFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/iface.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := c.GetType("Store")
+	if store == nil {
+		t.Fatal("GetType(Store) not found")
+	}
+	want := "// Store the composed interface\ntype Store interface {\nGetter\nSetter\nio.Closer\n Len() int\n}\n"
+	if got := gocoder.ToCode(store); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+	want = "// Store the composed interface\ntype Store interface {\nio.Closer\n Len() int\n Get(ctx context.Context, key string) (string, error)\n Set(ctx context.Context, key string, value string) error\n}\n"
+	if got := gocoder.ToCode(store, gocoder.NewToCodeOpt().ExpandEmbeds(true)); got != want {
+		t.Errorf("ToCode() expand = %q, want %q", got, want)
+	}
+	names := make([]string, 0)
+	for _, f := range store.ExpandFuncs() {
+		names = append(names, f.GetName())
+	}
+	if want := []string{"Len", "Get", "Set"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ExpandFuncs() = %v, want %v", names, want)
+	}
+	if store.FuncByName("Set") == nil {
+		t.Errorf("FuncByName(Set) not found")
+	}
+
+	number := c.GetType("Number")
+	if number == nil {
+		t.Fatal("GetType(Number) not found")
+	}
+	if embeds := number.GetEmbeds(); len(embeds) != 1 || len(embeds[0].GetUnionTerms()) != 2 {
+		t.Fatalf("GetEmbeds() want the union of 2 terms")
+	}
+	want = "type Number interface {\n~int | ~int64\n String() string\n}\n"
+	if got := gocoder.ToCode(number, gocoder.NewToCodeOpt().ExpandEmbeds(true)); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+}
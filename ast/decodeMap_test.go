@@ -0,0 +1,53 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetMapTypeFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/registry.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := c.GetType("Registry")
+	if typ == nil {
+		t.Fatal("GetType(Registry) not found")
+	}
+	tests := []struct {
+		field string
+		kind  reflect.Kind
+		want  string
+	}{
+		{field: "Entries", kind: reflect.Map, want: "map[string]*Entry"},
+		{field: "Index", kind: reflect.Map, want: "map[Entry]int"},
+		{field: "Groups", kind: reflect.Map, want: "map[string][]Entry"},
+		{field: "Hash", kind: reflect.Array, want: "[16]uint8"},
+		{field: "Slots", kind: reflect.Array, want: "[4]*Entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			f := typ.FieldByName(tt.field)
+			if f == nil {
+				t.Fatalf("FieldByName(%s) not found", tt.field)
+			}
+			ft := f.GetType()
+			if ft.Kind() != tt.kind {
+				t.Errorf("Kind() = %v, want %v", ft.Kind(), tt.kind)
+			}
+			if got := ft.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	entries := typ.FieldByName("Entries").GetType()
+	if entries.Key().Kind() != reflect.String {
+		t.Errorf("Key().Kind() = %v, want string", entries.Key().Kind())
+	}
+	if elem := entries.Elem(); !elem.IsPtr() || !elem.Elem().IsStruct() {
+		t.Errorf("Elem() = %s, want pointer to struct", elem.String())
+	}
+	if got := typ.FieldByName("Slots").GetType().Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4", got)
+	}
+}
@@ -30,11 +30,11 @@ func (c *CodeDecoder) SearchEntityMethods(typePkg string, reviverTypeNameRegStr
 				if ts.Recv == nil || len(ts.Recv.List) == 0 {
 					return true
 				}
-				ctx := NewDecoderContextByAstFile(pkgV.Name, reviverTypeNameRegStr, astFile)
+				ctx := NewDecoderContextByAstFile(pkgV.Name, reviverTypeNameRegStr, astFile).WithTypeParams(receiverTypeParamNames(ts.Recv.List[0].Type)...)
 				reviverType := c.GetTypeFromASTNode(ctx, ts.Recv.List[0].Type)
 				name := ""
 				if reviverType != nil {
-					name = reviverType.String()
+					name = receiverTypeName(reviverType)
 				}
 				if !reviverTypeNameReg.MatchString(name) {
 					return true
@@ -79,11 +79,11 @@ func (c *CodeDecoder) GetMethods(reviverTypeName string) []gocoder.Func {
 				if ts.Recv == nil || len(ts.Recv.List) == 0 {
 					return true
 				}
-				ctx := NewDecoderContextByAstFile(pkgV.Name, typeTypeName, astFile)
+				ctx := NewDecoderContextByAstFile(pkgV.Name, typeTypeName, astFile).WithTypeParams(receiverTypeParamNames(ts.Recv.List[0].Type)...)
 				reviverType := c.GetTypeFromASTNode(ctx, ts.Recv.List[0].Type)
 				name := ""
 				if reviverType != nil {
-					name = reviverType.String()
+					name = receiverTypeName(reviverType)
 				}
 				if name != typeTypeName && name != "*"+typeTypeName {
 					return true
@@ -0,0 +1,56 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func TestGetMethodSetFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/methodset.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		ptr  bool
+		want []string
+	}{
+		{name: "Logger", ptr: false, want: []string{"Log"}},
+		{name: "Logger", ptr: true, want: []string{"Log", "SetLevel"}},
+		{name: "Closer", ptr: false, want: []string{"Close"}},
+		{name: "Closer", ptr: true, want: []string{}},
+		{name: "Service", ptr: false, want: []string{"Log", "Get", "Name", "Close"}},
+		{name: "Service", ptr: true, want: []string{"Start", "Log", "SetLevel", "Get", "Name", "Close"}},
+		{name: "Conflict", ptr: false, want: []string{}},
+		{name: "Shadow", ptr: true, want: []string{"SetLevel"}},
+	}
+	for _, tt := range tests {
+		typ := c.GetType(tt.name)
+		if typ == nil {
+			t.Fatalf("GetType(%s) not found", tt.name)
+		}
+		if tt.ptr {
+			typ = typ.TackPtr()
+		}
+		got := make([]string, 0)
+		for _, f := range typ.MethodSet() {
+			got = append(got, f.GetName())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s ptr %v MethodSet() = %v, want %v", tt.name, tt.ptr, got, tt.want)
+		}
+	}
+
+	setLevel := gocoder.NewInterface("LevelSetter", []gocoder.Func{
+		gocoder.NewFunc(gocoder.FuncTypeDefault, "SetLevel", nil, []gocoder.Arg{gocoder.NewArg("level", gocoder.NewTypeName("int"), false)}, nil),
+	})
+	service := c.GetType("Service")
+	if service.Implements(setLevel) || !service.TackPtr().Implements(setLevel) {
+		t.Errorf("want only *Service implements LevelSetter")
+	}
+	if !service.Implements(c.GetType("Closer")) {
+		t.Errorf("want Service implements Closer by the embedded interface")
+	}
+}
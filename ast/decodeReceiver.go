@@ -2,6 +2,7 @@ package ast
 
 import (
 	"go/ast"
+	"strings"
 
 	"github.com/liasece/gocoder"
 )
@@ -16,3 +17,12 @@ func (c *CodeDecoder) GetReceiverFromASTField(ctx DecoderContext, st *ast.Field)
 	res.AddNotes(c.GetNoteFromCommentGroup(ctx, st.Doc, st.Comment)...)
 	return res
 }
+
+// receiverTypeName get the receiver type name without type arguments, like `*List` for `*List[T]`
+func receiverTypeName(typ gocoder.Type) string {
+	name := typ.String()
+	if index := strings.Index(name, "["); index > 0 {
+		name = name[:index]
+	}
+	return name
+}
@@ -0,0 +1,66 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/liasece/gocoder"
+)
+
+type testDecimal struct {
+	value int64
+	exp   int32
+}
+
+func TestTypeRegistry(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/external.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.RegisterType("go.mongodb.org/mongo-driver/bson/primitive.ObjectID", gocoder.NewTypeDetail("go.mongodb.org/mongo-driver/bson/primitive", "ObjectID"))
+	c.RegisterType("github.com/google/uuid.UUID", gocoder.NewTypeDetail("github.com/google/uuid", "UUID"))
+	c.RegisterType("github.com/shopspring/decimal.Decimal", reflect.TypeOf(testDecimal{}))
+	order := c.GetType("Order")
+	if order == nil {
+		t.Fatal("GetType(Order) not found")
+	}
+	want := map[string]string{
+		"ID":     "primitive.ObjectID",
+		"Trace":  "uuid.UUID",
+		"Amount": "ast.testDecimal",
+		"Items":  "[]*uuid.UUID",
+	}
+	if order.NumField() != len(want) {
+		t.Fatalf("NumField() = %d, want %d", order.NumField(), len(want))
+	}
+	for i := 0; i < order.NumField(); i++ {
+		f := order.Field(i)
+		if got := typeRefCode(f.GetType()); got != want[f.GetName()] {
+			t.Errorf("%s type = %q, want %q", f.GetName(), got, want[f.GetName()])
+		}
+	}
+	if order.Field(2).GetType().Kind() != reflect.Struct {
+		t.Errorf("Amount Kind() = %v, want struct", order.Field(2).GetType().Kind())
+	}
+
+	// the registry of decoder is a copy, the default registry isn't changed
+	if TypeStringToZeroInterface("github.com/google/uuid.UUID") != nil {
+		t.Errorf("TypeStringToZeroInterface(uuid.UUID) registered to the default registry")
+	}
+	other, err := NewCodeDecoder("../test/source/testdata/external.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.TypeStringToZeroInterface("github.com/google/uuid.UUID") != nil {
+		t.Errorf("TypeStringToZeroInterface(uuid.UUID) registered to the other decoder")
+	}
+	DefaultTypeRegistry.Register("github.com/google/uuid.UUID", gocoder.NewTypeDetail("github.com/google/uuid", "UUID"))
+	defer DefaultTypeRegistry.Delete("github.com/google/uuid.UUID")
+	if typ := TypeStringToZeroInterface("github.com/google/uuid.UUID"); typ == nil || typ.ShowString() != "github.com/google/uuid.UUID" {
+		t.Errorf("TypeStringToZeroInterface(uuid.UUID) = %v, want the registered type", typ)
+	}
+	if typ := TypeStringToZeroInterface("time.Time"); typ == nil || typ.RefType() != reflect.TypeOf(time.Time{}) {
+		t.Errorf("TypeStringToZeroInterface(time.Time) = %v, want time.Time", typ)
+	}
+}
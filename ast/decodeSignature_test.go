@@ -0,0 +1,53 @@
+package ast
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func TestGetSignatureFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/signature.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := c.GetInterface("Service")
+	if service == nil {
+		t.Fatal("GetInterface(Service) not found")
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Copy", want: "func(dst []uint8, src []uint8) (n int, err error)"},
+		{name: "Log", want: "func(format string, args ...interface{})"},
+		{name: "Apply", want: "func(opts ...Option) error"},
+		{name: "Watch", want: "func(ctx context.Context, onEvent func(name string, ok bool) error, done <-chan struct{}) (<-chan string, error)"},
+		{name: "Pair", want: "func() (string, string)"},
+		{name: "Swap", want: "func(a int, b int, _ int) (x int, y int)"},
+	}
+	for _, tt := range tests {
+		f := service.FuncByName(tt.name)
+		if f == nil {
+			t.Fatalf("FuncByName(%s) not found", tt.name)
+		}
+		if got := typeRefCode(f.Signature()); got != tt.want {
+			t.Errorf("%s signature = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// the mock methods regenerated from the interface must be valid go code
+	mock := gocoder.NewTypeName("Mock").TackPtr()
+	code := gocoder.NewCode()
+	for _, f := range service.GetFuncs() {
+		code.C(gocoder.NewFunc(gocoder.FuncTypeDefault, f.GetName(), gocoder.NewReceiver("m", mock), f.GetArgs(), f.GetReturns()).C(
+			gocoder.NewValue("panic", nil).Call(gocoder.NewValueI("not implemented")),
+		))
+	}
+	src := "package mock\n\n" + gocoder.ToCode(code, gocoder.NewToCodeOpt().PkgPath("github.com/liasece/gocoder/test/source/testdata"))
+	if _, err := parser.ParseFile(token.NewFileSet(), "mock.go", src, parser.AllErrors); err != nil {
+		t.Errorf("parse regenerated methods: %v\n%s", err, src)
+	}
+}
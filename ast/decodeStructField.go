@@ -2,6 +2,7 @@ package ast
 
 import (
 	"go/ast"
+	"strconv"
 	"strings"
 
 	"github.com/liasece/gocoder"
@@ -24,10 +25,8 @@ func (c *CodeDecoder) GetStructFieldFromASTStruct(ctx DecoderContext, st *ast.St
 		}
 
 		if name == "" {
-			// 匿名成员结构体
-			for i := 0; i < typ.NumField(); i++ {
-				fields = append(fields, typ.Field(i))
-			}
+			// embedded field, like `Base` or `*pkg.Base`
+			fields = append(fields, c.getEmbeddedFieldFromASTField(ctx, astField, typ))
 			continue
 		}
 
@@ -43,6 +42,25 @@ func (c *CodeDecoder) GetStructFieldFromASTStruct(ctx DecoderContext, st *ast.St
 	return fields
 }
 
+// getTagFromASTField the tag without quotes, the tag literal can be a raw string or an interpreted string
+func getTagFromASTField(astField *ast.Field) string {
+	if astField.Tag == nil {
+		return ""
+	}
+	if tag, err := strconv.Unquote(astField.Tag.Value); err == nil {
+		return tag
+	}
+	return strings.ReplaceAll(astField.Tag.Value, "`", "")
+}
+
+func (c *CodeDecoder) getEmbeddedFieldFromASTField(ctx DecoderContext, astField *ast.Field, typ gocoder.Type) gocoder.Field {
+	tag := getTagFromASTField(astField)
+	f := gocoder.NewEmbeddedField(typ, tag)
+	f.AddNotes(c.GetNoteFromCommentGroup(ctx, astField.Doc)...)
+	f.AddNotes(c.GetNoteFromCommentGroup(ctx, astField.Comment)...)
+	return f
+}
+
 func (c *CodeDecoder) GetStructFieldFromASTField(ctx DecoderContext, astField *ast.Field) gocoder.Field {
 	name := ""
 	if len(astField.Names) > 0 {
@@ -53,10 +71,7 @@ func (c *CodeDecoder) GetStructFieldFromASTField(ctx DecoderContext, astField *a
 		log.Debug("GetStructFieldFromASTStruct not found type", log.Any("astType", astField.Type), log.Any("astField", astField))
 		return nil
 	}
-	var tag string
-	if astField.Tag != nil {
-		tag = strings.ReplaceAll(astField.Tag.Value, "`", "")
-	}
+	tag := getTagFromASTField(astField)
 	f := gocoder.NewField(name, typ, tag)
 	f.AddNotes(c.GetNoteFromCommentGroup(ctx, astField.Doc)...)
 	f.AddNotes(c.GetNoteFromCommentGroup(ctx, astField.Comment)...)
@@ -0,0 +1,33 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestGetStructTagFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/tag.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := c.GetType("User")
+	if user == nil {
+		t.Fatal("GetType(User) not found")
+	}
+	id, err := user.FieldByName("ID").GetStructTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.GetName("json") != "id" || !id.HasOption("json", "omitempty") || id.Get("bson") != "_id" {
+		t.Errorf("ID tag = %q", id.String())
+	}
+	if got := user.FieldByName("Name").GetTag(); got != `json:"name"` {
+		t.Errorf("Name GetTag() = %q, want %q", got, `json:"name"`)
+	}
+	email, err := user.FieldByName("Email").GetStructTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := email.Keys(); len(got) != 0 {
+		t.Errorf("Email Keys() = %v, want empty", got)
+	}
+}
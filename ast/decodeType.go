@@ -2,8 +2,10 @@ package ast
 
 import (
 	"go/ast"
+	"go/token"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/liasece/gocoder"
@@ -29,26 +31,86 @@ func (c *CodeDecoder) getTypeFromASTNodeWithName(ctx DecoderContext, st ast.Node
 		return nil
 	case *ast.SelectorExpr:
 		// like time.Time
+		if pkg, name, ok := c.typeNameOf(t.Sel); ok {
+			return c.getLoadedType(pkg, name)
+		}
 		pkgName := ctx.GetPkgByAlias(t.X.(*ast.Ident).Name)
 		return c.GetType(pkgName + "." + t.Sel.Name)
 	case *ast.TypeSpec:
+		typeParams, ctx := c.GetTypeParamsFromASTFieldList(ctx, t.TypeParams)
 		res := c.getTypeFromASTNodeWithName(ctx, t.Type)
 		if res != nil {
-			res.AddNotes(c.GetNoteFromCommentGroup(ctx, t.Comment, t.Doc)...)
-			if res.Name() != t.Name.Name {
+			if t.Assign.IsValid() {
+				// like `type Foo = Bar`
+				res = res.WarpAlias(t.Name.Name)
+				res.SetPkg(ctx.GetCurrentPkg())
+			} else if res.Name() != t.Name.Name {
 				res = res.WarpNamed(t.Name.Name)
 				res.SetPkg(ctx.GetCurrentPkg())
 			}
+			res.AddNotes(c.GetNoteFromCommentGroup(ctx, t.Comment, t.Doc)...)
+			res.SetTypeParams(typeParams)
 		}
 		return res
+	case *ast.IndexExpr:
+		// like `List[T]`
+		res := c.getTypeFromASTNodeWithName(ctx, t.X)
+		args := c.getTypeArgsFromASTExprs(ctx, t.Index)
+		if res == nil || args == nil {
+			return nil
+		}
+		return res.Instantiate(args...)
+	case *ast.IndexListExpr:
+		// like `Map[K, V]`
+		res := c.getTypeFromASTNodeWithName(ctx, t.X)
+		args := c.getTypeArgsFromASTExprs(ctx, t.Indices...)
+		if res == nil || args == nil {
+			return nil
+		}
+		return res.Instantiate(args...)
+	case *ast.UnaryExpr:
+		// like `~int` in type constraint
+		if t.Op != token.TILDE {
+			log.Warn("name == typeName but type operator unknown", log.Any("name", ctx.GetBuildingItemName()), log.Any("op", t.Op.String()))
+			return nil
+		}
+		res := c.getTypeFromASTNodeWithName(ctx, t.X)
+		if res == nil {
+			return nil
+		}
+		return gocoder.NewTypeApprox(res)
+	case *ast.BinaryExpr:
+		// like `~int | ~string` in type constraint
+		if t.Op != token.OR {
+			log.Warn("name == typeName but type operator unknown", log.Any("name", ctx.GetBuildingItemName()), log.Any("op", t.Op.String()))
+			return nil
+		}
+		terms := c.getTypeUnionTermsFromASTExpr(ctx, t)
+		if terms == nil {
+			return nil
+		}
+		return gocoder.NewTypeUnion(terms...)
 	case *ast.StructType:
 		return c.GetTypeFromASTStructType(ctx, t)
 	case *ast.ArrayType:
 		res := c.getTypeFromASTNodeWithName(ctx, t.Elt)
-		if res != nil {
+		if res == nil {
+			return nil
+		}
+		if t.Len == nil {
 			return res.Slice()
 		}
-		return nil
+		// like `[4]byte`, only the basic literal length is supported
+		lit, ok := t.Len.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			log.Warn("array length unsupported", log.Any("name", ctx.GetBuildingItemName()), log.Any("len", reflect.TypeOf(t.Len)))
+			return nil
+		}
+		length, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return nil
+		}
+		return gocoder.NewTypeArray(int(length), res)
 	case *ast.MapType:
 		key := c.getTypeFromASTNodeWithName(ctx, t.Key)
 		if key == nil {
@@ -58,7 +120,22 @@ func (c *CodeDecoder) getTypeFromASTNodeWithName(ctx DecoderContext, st ast.Node
 		if value == nil {
 			return nil
 		}
-		return gocoder.NewType(reflect.MapOf(key.RefType(), value.RefType()))
+		return gocoder.NewTypeMap(key, value)
+	case *ast.ChanType:
+		elem := c.getTypeFromASTNodeWithName(ctx, t.Value)
+		if elem == nil {
+			return nil
+		}
+		dir := reflect.BothDir
+		switch t.Dir {
+		case ast.SEND:
+			dir = reflect.SendDir
+		case ast.RECV:
+			dir = reflect.RecvDir
+		}
+		return gocoder.NewTypeChan(elem, dir)
+	case *ast.FuncType:
+		return c.GetTypeFromASTFuncType(ctx, t)
 	case *ast.InterfaceType:
 		res := c.GetInterfaceFromASTInterfaceType(ctx, t)
 		return res
@@ -74,8 +151,17 @@ func (c *CodeDecoder) GetTypeFromASTNode(ctx DecoderContext, st ast.Node) gocode
 
 func (c *CodeDecoder) GetTypeFromASTIdent(ctx DecoderContext, st *ast.Ident) gocoder.Type {
 	typeStr := st.Name
-	res := TypeStringToZeroInterface(typeStr)
+	if ctx != nil {
+		if res := ctx.GetTypeParam(typeStr); res != nil {
+			return res
+		}
+	}
+	res := c.TypeStringToZeroInterface(typeStr)
 	if res == nil {
+		if pkg, name, ok := c.typeNameOf(st); ok {
+			// like the type of dot import
+			return c.getLoadedType(pkg, name)
+		}
 		// not basic type
 		if ctx != nil && ctx.GetCurrentPkg() != "" {
 			typeStr = ctx.GetCurrentPkg() + "." + typeStr
@@ -142,17 +228,17 @@ func (c *CodeDecoder) GetType(fullTypeName string) gocoder.Type {
 	c.DecodedTypes[fullTypeName] = astLoadedType
 
 	var resType gocoder.Type
-	basicType := TypeStringToZeroInterface(fullTypeName)
+	basicType := c.TypeStringToZeroInterface(fullTypeName)
 	if basicType != nil {
 		resType = basicType
 	}
+	typeTypeName := fullTypeName
+	typePkg := ""
+	if index := strings.LastIndex(fullTypeName, "."); index > 0 && index < len(fullTypeName)-1 {
+		typePkg = fullTypeName[:index]
+		typeTypeName = fullTypeName[index+1:]
+	}
 	if resType == nil {
-		typeTypeName := fullTypeName
-		typePkg := ""
-		if index := strings.LastIndex(fullTypeName, "."); index > 0 && index < len(fullTypeName)-1 {
-			typePkg = fullTypeName[:index]
-			typeTypeName = fullTypeName[index+1:]
-		}
 		for _, pkgV := range c.pkgs.List {
 			if typePkg != "" && typePkg != pkgV.Name && typePkg != pkgV.Alias {
 				continue
@@ -177,6 +263,7 @@ func (c *CodeDecoder) GetType(fullTypeName string) gocoder.Type {
 										resType.SetPkg(pkgV.Name)
 									}
 									resType.AddNotes(c.GetNoteFromCommentGroup(ctx, astGenDecl.Doc)...)
+									typePkg = pkgV.Name
 									return false
 								}
 							}
@@ -197,6 +284,10 @@ func (c *CodeDecoder) GetType(fullTypeName string) gocoder.Type {
 		c.DecodedTypes[fullTypeName] = nil
 	} else {
 		astLoadedType.Type = resType
+		if basicType == nil && resType.Kind() != reflect.Interface {
+			// the receiver of method may refer to this type, so attach the methods after the type is loaded
+			resType.AddMethods(c.GetMethods(typePkg + "." + typeTypeName))
+		}
 	}
 	return resType
 }
@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func TestGetTypeMethodsFromSource(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/method.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter := c.GetType("Counter")
+	if counter == nil {
+		t.Fatal("GetType(Counter) not found")
+	}
+	if got := len(counter.GetMethods()); got != 2 {
+		t.Fatalf("GetMethods() len = %d, want 2", got)
+	}
+	if f := counter.FuncByName("Value"); f == nil || len(f.GetReturns()) != 1 {
+		t.Errorf("FuncByName(Value) want the method with one return")
+	}
+	if f := counter.FuncByName("N"); f != nil {
+		t.Errorf("FuncByName(N) = %s, want nil", f.GetName())
+	}
+	opt := gocoder.NewToCodeOpt().PkgPath("github.com/liasece/gocoder/test/source/testdata")
+	if got, want := gocoder.ToCode(counter, opt), "type Counter struct {\nN int\n}\n"; got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+	want := "type Counter struct {\nN int\n}\n\n// Inc add one\nfunc(c *Counter) Inc() {\n}\n\nfunc(c Counter) Value() int {\n}\n"
+	if got := gocoder.ToCode(counter, opt.Methods(true)); got != want {
+		t.Errorf("ToCode() with methods = %q, want %q", got, want)
+	}
+
+	level := c.GetType("Level")
+	if level == nil || level.FuncByName("String") == nil {
+		t.Errorf("FuncByName(String) of Level not found")
+	}
+	if namer := c.GetType("Namer"); namer == nil || len(namer.GetMethods()) != 0 || namer.FuncByName("Name") == nil {
+		t.Errorf("Namer want interface funcs without methods")
+	}
+}
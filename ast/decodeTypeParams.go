@@ -0,0 +1,80 @@
+package ast
+
+import (
+	"go/ast"
+
+	"github.com/liasece/gocoder"
+)
+
+// GetTypeParamsFromASTFieldList decode type parameters like `[K comparable, V any]`,
+// the returned context can resolve these type parameters.
+func (c *CodeDecoder) GetTypeParamsFromASTFieldList(ctx DecoderContext, st *ast.FieldList) ([]gocoder.Arg, DecoderContext) {
+	if st == nil || len(st.List) == 0 {
+		return nil, ctx
+	}
+	names := make([]string, 0, len(st.List))
+	for _, field := range st.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	// constraints can refer to each other, like `[S ~[]E, E any]`
+	ctx = ctx.WithTypeParams(names...)
+	res := make([]gocoder.Arg, 0, len(names))
+	for _, field := range st.List {
+		constraint := c.GetTypeFromASTNode(ctx, field.Type)
+		for _, name := range field.Names {
+			res = append(res, gocoder.NewTypeParam(name.Name, constraint))
+		}
+	}
+	return res, ctx
+}
+
+func (c *CodeDecoder) getTypeArgsFromASTExprs(ctx DecoderContext, exprs ...ast.Expr) []interface{} {
+	res := make([]interface{}, 0, len(exprs))
+	for _, expr := range exprs {
+		typ := c.getTypeFromASTNodeWithName(ctx, expr)
+		if typ == nil {
+			return nil
+		}
+		res = append(res, typ)
+	}
+	return res
+}
+
+func (c *CodeDecoder) getTypeUnionTermsFromASTExpr(ctx DecoderContext, expr ast.Expr) []gocoder.Type {
+	if t, ok := expr.(*ast.BinaryExpr); ok {
+		left := c.getTypeUnionTermsFromASTExpr(ctx, t.X)
+		right := c.getTypeUnionTermsFromASTExpr(ctx, t.Y)
+		if left == nil || right == nil {
+			return nil
+		}
+		return append(left, right...)
+	}
+	typ := c.getTypeFromASTNodeWithName(ctx, expr)
+	if typ == nil {
+		return nil
+	}
+	return []gocoder.Type{typ}
+}
+
+// receiverTypeParamNames get the type parameter names declared by a receiver, like `T` in `(l *List[T])`
+func receiverTypeParamNames(expr ast.Expr) []string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	var indices []ast.Expr
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		indices = []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		indices = t.Indices
+	}
+	res := make([]string, 0, len(indices))
+	for _, v := range indices {
+		if ident, ok := v.(*ast.Ident); ok && ident.Name != "_" {
+			res = append(res, ident.Name)
+		}
+	}
+	return res
+}
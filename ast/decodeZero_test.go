@@ -0,0 +1,36 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/liasece/gocoder"
+)
+
+func TestDecodedTypeZero(t *testing.T) {
+	c, err := NewCodeDecoder("../test/source/testdata/alias.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Status", want: `""`},
+		{name: "Deadline", want: "time.Time{}"},
+		{name: "Order", want: "Order{}"},
+		{name: "OrderAlias", want: "Order{}"},
+		{name: "Orders", want: "(Orders)(nil)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := c.GetType(tt.name)
+			if typ == nil {
+				t.Fatalf("GetType(%s) not found", tt.name)
+			}
+			got := gocoder.ToCode(typ.Unalias().Zero(), gocoder.NewToCodeOpt().PkgPath("github.com/liasece/gocoder/test/source/testdata"))
+			if got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/liasece/gocoder"
+	"golang.org/x/tools/go/packages"
+)
+
+// LoaderOption type
+type LoaderOption struct {
+	dir    *string
+	tags   []string
+	tests  *bool
+	online *bool
+}
+
+// NewLoaderOpt func
+func NewLoaderOpt() *LoaderOption {
+	return &LoaderOption{
+		dir:    nil,
+		tags:   nil,
+		tests:  nil,
+		online: nil,
+	}
+}
+
+// Dir func, the directory to run the go command in, like the root of module
+func (o *LoaderOption) Dir(v string) *LoaderOption {
+	o.dir = &v
+	return o
+}
+
+// Tags func, the build tags
+func (o *LoaderOption) Tags(v ...string) *LoaderOption {
+	o.tags = append(o.tags, v...)
+	return o
+}
+
+// Tests func, load the test files of packages too
+func (o *LoaderOption) Tests(v bool) *LoaderOption {
+	o.tests = &v
+	return o
+}
+
+// Online func, allow the go command to download the missing modules, the default only use vendor and the module cache
+func (o *LoaderOption) Online(v bool) *LoaderOption {
+	o.online = &v
+	return o
+}
+
+// MergeLoaderOpt func
+func MergeLoaderOpt(opts ...*LoaderOption) *LoaderOption {
+	res := NewLoaderOpt()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.dir != nil {
+			res.dir = opt.dir
+		}
+		res.tags = append(res.tags, opt.tags...)
+		if opt.tests != nil {
+			res.tests = opt.tests
+		}
+		if opt.online != nil {
+			res.online = opt.online
+		}
+	}
+	return res
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// NewCodeDecoderByPackages load packages by go/packages and check them by go/types, the patterns are the same as
+// `go list`, like `./...` or `github.com/liasece/gocoder/ast`. The dependencies are loaded too, so the identifiers
+// are resolved to their real packages and types, even the packages aren't in the patterns
+func NewCodeDecoderByPackages(patterns []string, opts ...*LoaderOption) (*CodeDecoder, error) {
+	opt := MergeLoaderOpt(opts...)
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax |
+			packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+		Fset: fset,
+		Env:  os.Environ(),
+	}
+	if opt.dir != nil {
+		cfg.Dir = *opt.dir
+	}
+	if len(opt.tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(opt.tags, ",")}
+	}
+	if opt.tests != nil {
+		cfg.Tests = *opt.tests
+	}
+	if opt.online == nil || !*opt.online {
+		cfg.Env = append(cfg.Env, "GOPROXY=off")
+	}
+	roots, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range roots {
+		for _, e := range pkg.Errors {
+			if e.Kind == packages.ListError || e.Kind == packages.ParseError {
+				return nil, fmt.Errorf("load package %s: %w", pkg.ID, e)
+			}
+		}
+	}
+
+	c := &CodeDecoder{
+		fset: fset,
+		pkgs: &Packages{
+			List: nil,
+		},
+		DecodedTypes: make(map[string]*LoadedType),
+		uses:         make(map[*ast.Ident]types.Object),
+		registry:     DefaultTypeRegistry.Clone(),
+	}
+	// the types.Sizes of go/packages is missing with the new go command, so check the packages here
+	sizes := types.SizesFor("gc", build.Default.GOARCH)
+	checked := make(map[string]*types.Package)
+	// the imports are visited before the importers
+	packages.Visit(roots, nil, func(pkg *packages.Package) {
+		if pkg.Name == "main" && strings.HasSuffix(pkg.PkgPath, ".test") {
+			// the generated main package of tests
+			return
+		}
+		checked[pkg.ID] = c.checkPackage(pkg, checked, sizes)
+		c.addLoadedPackage(pkg)
+	})
+	c.rootPackagesFirst(roots)
+	return c, nil
+}
+
+// checkPackage type check the declarations of package, the errors are ignored to check the incomplete package as much as possible
+func (c *CodeDecoder) checkPackage(pkg *packages.Package, checked map[string]*types.Package, sizes types.Sizes) *types.Package {
+	conf := &types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "unsafe" {
+				return types.Unsafe, nil
+			}
+			if imp := pkg.Imports[path]; imp != nil && checked[imp.ID] != nil {
+				return checked[imp.ID], nil
+			}
+			return nil, fmt.Errorf("package %s not loaded", path)
+		}),
+		IgnoreFuncBodies: true,
+		FakeImportC:      true,
+		Error:            func(error) {},
+		Sizes:            sizes,
+	}
+	info := &types.Info{
+		Uses: c.uses,
+	}
+	res, _ := conf.Check(pkg.PkgPath, c.fset, pkg.Syntax, info)
+	return res
+}
+
+// addLoadedPackage the test variant of package replaces the package, it has more files
+func (c *CodeDecoder) addLoadedPackage(pkg *packages.Package) {
+	files := make(map[string]*ast.File, len(pkg.Syntax))
+	for _, f := range pkg.Syntax {
+		files[c.fset.Position(f.Package).Filename] = f
+	}
+	if exist := c.pkgs.Get(pkg.PkgPath); exist != nil {
+		if len(exist.Package.Files) < len(files) {
+			exist.Package.Files = files
+		}
+		return
+	}
+	c.pkgs.Add(&Package{
+		Package: &ast.Package{
+			Name:    pkg.Name,
+			Scope:   nil,
+			Imports: nil,
+			Files:   files,
+		},
+		Name:  pkg.PkgPath,
+		Alias: pkg.Name,
+	})
+}
+
+// rootPackagesFirst the unqualified names are looked up in order, so the root packages go before their dependencies
+func (c *CodeDecoder) rootPackagesFirst(roots []*packages.Package) {
+	isRoot := make(map[string]bool, len(roots))
+	for _, pkg := range roots {
+		isRoot[pkg.PkgPath] = true
+	}
+	list := make([]*Package, 0, len(c.pkgs.List))
+	for _, pkg := range c.pkgs.List {
+		if isRoot[pkg.Name] {
+			list = append(list, pkg)
+		}
+	}
+	for _, pkg := range c.pkgs.List {
+		if !isRoot[pkg.Name] {
+			list = append(list, pkg)
+		}
+	}
+	c.pkgs.List = list
+}
+
+// typeNameOf the real package path and name of the type identifier, only in the packages loader mode
+func (c *CodeDecoder) typeNameOf(ident *ast.Ident) (string, string, bool) {
+	obj, ok := c.uses[ident].(*types.TypeName)
+	if !ok || obj.Pkg() == nil {
+		return "", "", false
+	}
+	return obj.Pkg().Path(), obj.Name(), true
+}
+
+// getLoadedType the type resolved by go/types, it's qualified by the real package even it's an interface
+func (c *CodeDecoder) getLoadedType(pkg string, name string) gocoder.Type {
+	res := c.GetType(pkg + "." + name)
+	if res != nil && res.Kind() == reflect.Interface && res.Package() == "" {
+		res.SetPkg(pkg)
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestNewCodeDecoderByPackages(t *testing.T) {
+	c, err := NewCodeDecoderByPackages([]string{"../test/source/testdata/loader/service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := c.GetType("Report")
+	if report == nil || report.NumField() != 2 {
+		t.Fatalf("GetType(Report) = %v, want 2 fields", report)
+	}
+	owner := report.Field(0).GetType()
+	if owner.Package() != "github.com/liasece/gocoder/test/source/testdata/loader/model" || owner.GetNamed() != "User" {
+		t.Errorf("Owner type = %s, want model.User", owner.ShowString())
+	}
+	if ms := owner.TackPtr().MethodSet(); len(ms) != 1 || ms[0].GetName() != "Name" {
+		t.Errorf("*User MethodSet() = %v, want Name", ms)
+	}
+	body := report.Field(1).GetType()
+	if body == nil || body.Package() != "io" || body.GetNamed() != "Reader" || len(body.ExpandFuncs()) != 1 {
+		t.Errorf("Body type = %v, want io.Reader decoded from source", body)
+	}
+
+	// the dot import resolves to the real package
+	audit := c.GetType("service.Audit")
+	if audit == nil || audit.NumField() != 2 || audit.Field(0).GetType().Elem().Package() != owner.Package() {
+		t.Errorf("GetType(service.Audit) = %v, want By of *model.User", audit)
+	}
+
+	svc := c.GetInterface("UserService")
+	if svc == nil {
+		t.Fatal("GetInterface(UserService) not found")
+	}
+	want := map[string]string{
+		"Get":    "func(ctx context.Context, id string) (*model.User, error)",
+		"Export": "func(w io.Writer, users ...model.User) error",
+	}
+	for _, f := range svc.GetFuncs() {
+		if got := typeRefCode(f.Signature()); got != want[f.GetName()] {
+			t.Errorf("%s type = %q, want %q", f.GetName(), got, want[f.GetName()])
+		}
+	}
+}
+
+func TestNewCodeDecoderByPackagesRootFirst(t *testing.T) {
+	// the root declares Reader and imports io, which declares Reader too
+	c, err := NewCodeDecoderByPackages([]string{"../test/source/testdata/loader/stream"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := c.GetType("Reader")
+	if reader == nil || !reader.IsStruct() || reader.FieldByName("X") == nil {
+		t.Fatalf("GetType(Reader) = %v, want the struct of root package", reader)
+	}
+	if src := reader.FieldByName("Src").GetType(); src.Package() != "io" || src.GetNamed() != "Reader" {
+		t.Errorf("Src type = %s, want io.Reader", src.ShowString())
+	}
+}
+
+func TestNewCodeDecoderByPackagesError(t *testing.T) {
+	if _, err := NewCodeDecoderByPackages([]string{"github.com/liasece/gocoder/not/exist"}); err == nil {
+		t.Errorf("NewCodeDecoderByPackages() want error of missing package")
+	}
+}
@@ -0,0 +1,88 @@
+package ast
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/liasece/gocoder"
+)
+
+// TypeRegistry the known types of other modules by the fully qualified name, like `github.com/google/uuid.UUID`,
+// the decoder uses them instead of decoding the source of dependency
+type TypeRegistry interface {
+	Register(fullName string, typ interface{}) // typ is reflect.Type, gocoder.Type or a value of the type
+	Delete(fullName string)
+	Lookup(fullName string) gocoder.Type
+	Names() []string
+	Clone() TypeRegistry
+}
+
+var _ TypeRegistry = (*tTypeRegistry)(nil)
+
+type tTypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]gocoder.Type
+}
+
+// DefaultTypeRegistry the registry of TypeStringToZeroInterface, the new CodeDecoder copies it
+var DefaultTypeRegistry = NewTypeRegistry()
+
+func init() {
+	DefaultTypeRegistry.Register("time.Time", time.Time{})
+	DefaultTypeRegistry.Register("context.Context", gocoder.NewTypeDetail("context", "Context"))
+}
+
+// NewTypeRegistry func
+func NewTypeRegistry() TypeRegistry {
+	return &tTypeRegistry{
+		mu:    sync.RWMutex{},
+		types: make(map[string]gocoder.Type),
+	}
+}
+
+func (r *tTypeRegistry) Register(fullName string, typ interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[fullName] = gocoder.MustToType(typ)
+}
+
+func (r *tTypeRegistry) Delete(fullName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.types, fullName)
+}
+
+// Lookup the copy of registered type, nil if not registered
+func (r *tTypeRegistry) Lookup(fullName string) gocoder.Type {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if typ, ok := r.types[fullName]; ok {
+		return typ.Clone()
+	}
+	return nil
+}
+
+func (r *tTypeRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]string, 0, len(r.types))
+	for k := range r.types {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (r *tTypeRegistry) Clone() TypeRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := &tTypeRegistry{
+		mu:    sync.RWMutex{},
+		types: make(map[string]gocoder.Type, len(r.types)),
+	}
+	for k, v := range r.types {
+		res.types[k] = v
+	}
+	return res
+}
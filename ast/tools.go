@@ -17,7 +17,15 @@ func (w walker) Visit(node ast.Node) ast.Visitor {
 	return nil
 }
 
+// TypeStringToZeroInterface the predeclared types and the types of DefaultTypeRegistry
 func TypeStringToZeroInterface(str string) gocoder.Type {
+	if res := basicTypeStringToZeroInterface(str); res != nil {
+		return res
+	}
+	return DefaultTypeRegistry.Lookup(str)
+}
+
+func basicTypeStringToZeroInterface(str string) gocoder.Type {
 	switch str {
 	case "bool":
 		return gocoder.MustToType(false)
@@ -53,8 +61,6 @@ func TypeStringToZeroInterface(str string) gocoder.Type {
 		return gocoder.MustToType(complex128(0))
 	case "string":
 		return gocoder.MustToType("")
-	case "time.Time":
-		return gocoder.MustToType(time.Time{})
 
 	case "[]bool":
 		return gocoder.MustToType([]bool{})
@@ -97,8 +103,8 @@ func TypeStringToZeroInterface(str string) gocoder.Type {
 	case "error":
 		return gocoder.NewTypeName("error")
 
-	case "context.Context":
-		return gocoder.NewTypeDetail("context", "Context")
+	case "any", "comparable":
+		return gocoder.NewTypeName(str)
 	}
 	return nil
 }
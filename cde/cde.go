@@ -34,6 +34,68 @@ func ForRange(autoSet bool, toValues gocoder.Value, value gocoder.Value, cs ...g
 	return gocoder.NewForRange(autoSet, gocoder.FuncTypeDefault, toValues, value, cs...)
 }
 
+// For func, like `for init; cond; post {}`
+func For(init gocoder.Value, cond gocoder.Value, post gocoder.Value, cs ...gocoder.Codable) gocoder.For {
+	return gocoder.NewFor(gocoder.FuncTypeDefault, init, cond, post, cs...)
+}
+
+// ForCond func, like `for cond {}`
+func ForCond(cond gocoder.Value, cs ...gocoder.Codable) gocoder.For {
+	return gocoder.NewFor(gocoder.FuncTypeDefault, nil, cond, nil, cs...)
+}
+
+// ForEver func, like `for {}`
+func ForEver(cs ...gocoder.Codable) gocoder.For {
+	return gocoder.NewFor(gocoder.FuncTypeDefault, nil, nil, nil, cs...)
+}
+
+// Label func
+func Label(name string, c gocoder.Codable) gocoder.Label {
+	return gocoder.NewLabel(name, c)
+}
+
+// Break func
+func Break(label ...string) gocoder.Branch {
+	return gocoder.NewBranch(gocoder.BranchKindBreak, optionalLabel(label))
+}
+
+// Continue func
+func Continue(label ...string) gocoder.Branch {
+	return gocoder.NewBranch(gocoder.BranchKindContinue, optionalLabel(label))
+}
+
+func optionalLabel(label []string) string {
+	if len(label) > 0 {
+		return label[0]
+	}
+	return ""
+}
+
+// Goto func
+func Goto(label string) gocoder.Branch {
+	return gocoder.NewBranch(gocoder.BranchKindGoto, label)
+}
+
+// Go func, like `go f()`
+func Go(v gocoder.Value) gocoder.Go {
+	return gocoder.NewGo(v)
+}
+
+// Defer func, like `defer f()`
+func Defer(v gocoder.Value) gocoder.Defer {
+	return gocoder.NewDefer(v)
+}
+
+// Recv func, like `<-ch`
+func Recv(ch gocoder.Value) gocoder.Value {
+	return ch.Recv()
+}
+
+// Send func, like `ch <- v`
+func Send(ch gocoder.Value, v interface{}) gocoder.Value {
+	return ch.Send(v)
+}
+
 // NoteLine func
 func NoteLine(content string) gocoder.Note {
 	return gocoder.MustToNote(gocoder.NoteKindLine, content)
@@ -59,6 +121,69 @@ func If(v gocoder.Value, cs ...gocoder.Codable) gocoder.If {
 	return gocoder.NewIf(v, cs...)
 }
 
+// Switch func
+func Switch(v gocoder.Value) gocoder.Switch {
+	return gocoder.NewSwitch(nil, v)
+}
+
+// SwitchInit func
+func SwitchInit(init gocoder.Value, v gocoder.Value) gocoder.Switch {
+	return gocoder.NewSwitch(init, v)
+}
+
+// TypeSwitch func
+func TypeSwitch(bind gocoder.Value, v gocoder.Value) gocoder.TypeSwitch {
+	return gocoder.NewTypeSwitch(bind, v)
+}
+
+// Select func
+func Select() gocoder.Select {
+	return gocoder.NewSelect()
+}
+
+// Const func
+func Const(specs ...gocoder.Spec) gocoder.Decl {
+	return gocoder.NewConst(specs...)
+}
+
+// Var func
+func Var(specs ...gocoder.Spec) gocoder.Decl {
+	return gocoder.NewVar(specs...)
+}
+
+// Spec func, typ and v can be nil, like `StatusB` in a iota sequence
+func Spec(name string, typ interface{}, v interface{}, notes ...gocoder.Note) gocoder.Spec {
+	var t gocoder.Type
+	if typ != nil {
+		t = Type(typ)
+	}
+	var vs []gocoder.Value
+	if v != nil {
+		vs = []gocoder.Value{Value("", v)}
+	}
+	return gocoder.NewSpec([]string{name}, t, vs, notes...)
+}
+
+// Iota func
+func Iota() gocoder.Value {
+	return gocoder.NewValueNameI("iota", int(0))
+}
+
+// Literal func, like `T{a, b}`
+func Literal(typ interface{}, elems ...interface{}) gocoder.Value {
+	return Type(typ).Literal(elems...)
+}
+
+// LiteralKV func, like `T{A: x, B: y}`
+func LiteralKV(typ interface{}, kvs ...interface{}) gocoder.Value {
+	return Type(typ).LiteralKV(kvs...)
+}
+
+// KV func, like `A: x` in composite literal
+func KV(key interface{}, value interface{}) gocoder.Value {
+	return gocoder.NewKeyValue(key, value)
+}
+
 // Type func
 func Type(i interface{}) gocoder.Type {
 	return gocoder.MustToType(i)
@@ -84,6 +209,11 @@ func Value(name string, i interface{}) gocoder.Value {
 	return gocoder.MustToValue(name, i)
 }
 
+// ValueLiteral func, encode a runtime value as go literal code
+func ValueLiteral(i interface{}) gocoder.Value {
+	return gocoder.NewValueLiteral(i)
+}
+
 // Return func
 func Return(is ...interface{}) gocoder.Return {
 	if len(is) == 0 {
@@ -115,6 +245,16 @@ func FuncInline(name string, args []gocoder.Arg, returns []gocoder.Arg, notes ..
 	return gocoder.NewFunc(gocoder.FuncTypeInline, name, nil, args, returns, notes...)
 }
 
+// Closure func, like `func(a int) error {}`
+func Closure(args []gocoder.Arg, returns []gocoder.Arg, cs ...gocoder.Codable) gocoder.Value {
+	return gocoder.NewFunc(gocoder.FuncTypeDefault, "", nil, args, returns).C(cs...).ToValue()
+}
+
+// ClosureInline func, like `func() { return }`
+func ClosureInline(args []gocoder.Arg, returns []gocoder.Arg, cs ...gocoder.Codable) gocoder.Value {
+	return gocoder.NewFunc(gocoder.FuncTypeInline, "", nil, args, returns).C(cs...).ToValue()
+}
+
 // Struct func
 func Struct(name string, fs ...gocoder.Field) gocoder.Type {
 	return gocoder.NewStruct(name, fs)
@@ -125,6 +265,32 @@ func Interface(name string, fs ...gocoder.Func) gocoder.Type {
 	return gocoder.NewInterface(name, fs)
 }
 
+// GenericStruct func, like `type Set[T comparable] struct {}`
+func GenericStruct(name string, typeParams []gocoder.Arg, fs ...gocoder.Field) gocoder.Type {
+	res := gocoder.NewStruct(name, fs)
+	res.SetTypeParams(typeParams)
+	return res
+}
+
+// GenericInterface func
+func GenericInterface(name string, typeParams []gocoder.Arg, fs ...gocoder.Func) gocoder.Type {
+	res := gocoder.NewInterface(name, fs)
+	res.SetTypeParams(typeParams)
+	return res
+}
+
+// GenericFunc func, like `func Map[T any, R any](...)`
+func GenericFunc(name string, typeParams []gocoder.Arg, args []gocoder.Arg, returns []gocoder.Arg, notes ...gocoder.Note) gocoder.Func {
+	res := gocoder.NewFunc(gocoder.FuncTypeDefault, name, nil, args, returns, notes...)
+	res.SetTypeParams(typeParams)
+	return res
+}
+
+// TypeParam func
+func TypeParam(name string, constraint interface{}) gocoder.Arg {
+	return gocoder.NewTypeParam(name, Type(constraint))
+}
+
 // Field func
 func Field(name string, typ interface{}, tag string) gocoder.Field {
 	return gocoder.NewField(name, Type(typ), tag)
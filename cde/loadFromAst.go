@@ -9,6 +9,11 @@ func TypeStringToZeroInterface(str string) gocoder.Type {
 	return ast.TypeStringToZeroInterface(str)
 }
 
+// RegisterType func, register the known type to ast.DefaultTypeRegistry, like `cde.RegisterType("github.com/google/uuid.UUID", uuid.UUID{})`
+func RegisterType(fullName string, typ interface{}) {
+	ast.DefaultTypeRegistry.Register(fullName, typ)
+}
+
 func GetTypeFromSource(path string, typeName string) (gocoder.Type, error) {
 	c, err := ast.NewCodeDecoder(path)
 	if err != nil {
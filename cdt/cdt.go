@@ -1,6 +1,7 @@
 package cdt
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/liasece/gocoder"
@@ -16,6 +17,36 @@ func Context() gocoder.Type {
 	return gocoder.NewTypeDetail("context", "Context")
 }
 
+// Any func
+func Any() gocoder.Type {
+	return gocoder.NewTypeName("any")
+}
+
+// Comparable func
+func Comparable() gocoder.Type {
+	return gocoder.NewTypeName("comparable")
+}
+
+// Approx func, like `~int`
+func Approx(t gocoder.Type) gocoder.Type {
+	return gocoder.NewTypeApprox(t)
+}
+
+// Union func, like `~int | ~string`
+func Union(terms ...gocoder.Type) gocoder.Type {
+	return gocoder.NewTypeUnion(terms...)
+}
+
+// Chan func, like `chan T`, `<-chan T` or `chan<- T`
+func Chan(elem gocoder.Type, dir reflect.ChanDir) gocoder.Type {
+	return gocoder.NewTypeChan(elem, dir)
+}
+
+// Func func, like `func(context.Context, string) (int, error)`
+func Func(args []gocoder.Arg, returns []gocoder.Arg) gocoder.Type {
+	return gocoder.NewTypeFunc(args, returns)
+}
+
 // I func
 func I(i interface{}) gocoder.Type {
 	return gocoder.NewTypeI(i)
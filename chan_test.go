@@ -0,0 +1,94 @@
+package gocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChanToCode(t *testing.T) {
+	ch := NewValueNameI("ch", make(chan int))
+	worker := NewValueNameI("worker", nil)
+	closeF := NewValueNameI("close", nil)
+	item := NewStruct("Item", nil)
+	sendOnly := NewTypeChan(item, reflect.SendDir)
+	sendOnly.SetInReference(true)
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "chan type",
+			code: NewTypeChan(NewTypeI(int(0)), reflect.BothDir),
+			want: "chan int",
+		},
+		{
+			name: "recv only chan type",
+			code: NewTypeChan(NewTypeI(""), reflect.RecvDir),
+			want: "<-chan string",
+		},
+		{
+			name: "send only symbolic chan type",
+			code: sendOnly,
+			want: "chan<- Item",
+		},
+		{
+			name: "chan of recv only chan",
+			code: NewTypeChan(NewTypeChan(NewTypeI(int(0)), reflect.RecvDir), reflect.BothDir),
+			want: "chan (<-chan int)",
+		},
+		{
+			name: "make chan of recv only symbolic chan",
+			code: NewValue("make", nil).Call(NewOnlyTypeValue(NewTypeChan(NewTypeChan(item, reflect.RecvDir), reflect.BothDir))),
+			want: "make(chan (<-chan Item))",
+		},
+		{
+			name: "send only chan of recv only chan",
+			code: NewTypeChan(NewTypeChan(NewTypeI(int(0)), reflect.RecvDir), reflect.SendDir),
+			want: "chan<- <-chan int",
+		},
+		{
+			name: "make",
+			code: NewValue("make", nil).Call(NewOnlyTypeValue(NewTypeChan(item, reflect.BothDir)), 10),
+			want: "make(chan Item, 10)",
+		},
+		{
+			name: "go",
+			code: NewGo(worker.Call(ch)),
+			want: "go worker(ch)",
+		},
+		{
+			name: "defer",
+			code: NewDefer(closeF.Call(ch)),
+			want: "defer close(ch)",
+		},
+		{
+			name: "recv",
+			code: NewValue("v", nil).AutoSet(ch.Recv()),
+			want: "v := <-ch",
+		},
+		{
+			name: "send",
+			code: ch.Send(1),
+			want: "ch <- 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanRecvType(t *testing.T) {
+	ch := NewValueNameI("ch", make(chan int))
+	if got := ch.Recv().Type(); got == nil || got.Kind() != reflect.Int {
+		t.Errorf("Recv().Type() = %v, want int", got)
+	}
+	typ := NewTypeChan(NewTypeI(int(0)), reflect.RecvDir)
+	if !typ.IsChan() || typ.GetChanDir() != reflect.RecvDir {
+		t.Errorf("IsChan() = %v, GetChanDir() = %v", typ.IsChan(), typ.GetChanDir())
+	}
+}
@@ -0,0 +1,114 @@
+package gocoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFuncCallCheckErr(t *testing.T) {
+	load := NewValue("load", nil)
+	id := NewValue("id", NewTypeI(""))
+	loadF := NewValueFunc("load", nil, nil, []Type{NewTypeI(0), NewTypeI(""), NewTypeName("error")})
+	tests := []struct {
+		name    string
+		returns []Arg
+		call    Value
+		opts    []*CheckErrOption
+		want    string
+	}{
+		{
+			name:    "error only",
+			returns: []Arg{NewArg("", NewTypeName("error"), false)},
+			call:    load.Call(id),
+			want:    "err := load(id)\nif err != nil {\n\treturn err\n}\n",
+		},
+		{
+			name:    "zero values",
+			returns: []Arg{NewArg("", NewTypeI(0), false), NewArg("", NewTypeI(""), false), NewArg("", NewTypeI(&time.Time{}), false), NewArg("", NewTypeName("error"), false)},
+			call:    load.Call(id),
+			opts:    []*CheckErrOption{NewCheckErrOpt().Names("n")},
+			want:    "n, err := load(id)\nif err != nil {\n\treturn 0, \"\", nil, err\n}\n",
+		},
+		{
+			name:    "decoded types",
+			returns: []Arg{NewArg("", NewStruct("Order", nil), false), NewArg("", NewTypeI("").WarpNamed("Status"), false), NewArg("", NewTypeName("error"), false)},
+			call:    load.Call(id),
+			opts:    []*CheckErrOption{NewCheckErrOpt().Names("order", "_")},
+			want:    "order, _, err := load(id)\nif err != nil {\n\treturn Order{}, \"\", err\n}\n",
+		},
+		{
+			name:    "wrap",
+			returns: []Arg{NewArg("n", NewTypeI(0), false), NewArg("err", NewTypeName("error"), false)},
+			call:    load.Call(id),
+			opts:    []*CheckErrOption{NewCheckErrOpt().Names("n").Wrap("load 100%")},
+			want:    "n, err = load(id)\nif err != nil {\n\treturn 0, fmt.Errorf(\"load 100%%: %w\", err)\n}\n",
+		},
+		{
+			name:    "named result err",
+			returns: []Arg{NewArg("n", NewTypeI(0), false), NewArg("err", NewTypeName("error"), false)},
+			call:    loadF.Call(id),
+			want:    "_, _, err = load(id)\nif err != nil {\n\treturn 0, err\n}\n",
+		},
+		{
+			name:    "inferred results",
+			returns: []Arg{NewArg("", NewTypeI(0), false), NewArg("", NewTypeName("error"), false)},
+			call:    loadF.Call(id),
+			want:    "_, _, err := load(id)\nif err != nil {\n\treturn 0, err\n}\n",
+		},
+		{
+			name:    "inferred results with names",
+			returns: []Arg{NewArg("", NewTypeName("error"), false)},
+			call:    loadF.Call(id),
+			opts:    []*CheckErrOption{NewCheckErrOpt().Names("n")},
+			want:    "n, _, err := load(id)\nif err != nil {\n\treturn err\n}\n",
+		},
+		{
+			name:    "inferred results of reflect func",
+			returns: []Arg{NewArg("", NewTypeName("error"), false)},
+			call:    NewValueNameI("parse", time.ParseDuration).Call(id),
+			opts:    []*CheckErrOption{NewCheckErrOpt().Names("d")},
+			want:    "d, err := parse(id)\nif err != nil {\n\treturn err\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFunc(FuncTypeDefault, "Get", nil, nil, tt.returns)
+			if got := ToCode(f.CallCheckErr(tt.call, tt.opts...)); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncCallCheckErrTwice(t *testing.T) {
+	id := NewValue("id", NewTypeI(""))
+	load := NewValueFunc("load", nil, nil, []Type{NewTypeI(0), NewTypeName("error")})
+	save := NewValueFunc("save", nil, nil, []Type{NewTypeName("error")})
+	f := NewFunc(FuncTypeDefault, "Get", nil, nil, []Arg{NewArg("", NewTypeI(0), false), NewArg("", NewTypeName("error"), false)})
+	code := NewCode().C(
+		f.CallCheckErr(load.Call(id), NewCheckErrOpt().Names("n")),
+		f.CallCheckErr(save.Call(NewValue("n", NewTypeI(0))), NewCheckErrOpt().Define(false)),
+	)
+	want := "n, err := load(id)\nif err != nil {\n\treturn 0, err\n}\nerr = save(n)\nif err != nil {\n\treturn 0, err\n}\n"
+	if got := ToCode(code); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+}
+
+func TestFuncCallCheckErrImports(t *testing.T) {
+	pkgTool := NewDefaultPkgTool()
+	f := NewFunc(FuncTypeDefault, "Get", nil, nil, []Arg{NewArg("", NewTypeName("error"), false)})
+	ToCode(f.CallCheckErr(NewValue("load", nil).Call(), NewCheckErrOpt().Wrap("load")), NewToCodeOpt().PkgTool(pkgTool))
+	if _, ok := pkgTool.PkgAliasMap()["fmt"]; !ok {
+		t.Errorf("PkgAliasMap() = %v, want fmt imported", pkgTool.PkgAliasMap())
+	}
+}
+
+func TestFuncCallCheckErrNoError(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("CallCheckErr() not panic")
+		}
+	}()
+	NewFunc(FuncTypeDefault, "Get", nil, nil, []Arg{NewArg("", NewTypeI(0), false)}).CallCheckErr(NewValue("load", nil).Call())
+}
@@ -0,0 +1,70 @@
+package gocoder
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClosureToCode(t *testing.T) {
+	ctx := NewArg("ctx", NewTypeI((*context.Context)(nil)).UnPtr(), false)
+	errRet := NewArg("", NewTypeName("error"), false)
+	handler := NewFunc(FuncTypeDefault, "", nil, []Arg{ctx}, []Arg{errRet}).C(NewReturn(NewValueNil()))
+	register := NewValueNameI("register", nil)
+	server := NewStruct("Server", []Field{NewField("OnClose", NewTypeFunc(nil, []Arg{errRet}), "")})
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "auto set",
+			code: NewValue("h", nil).AutoSet(handler.ToValue()),
+			want: "h := func(ctx context.Context) error {\n\treturn nil\n}",
+		},
+		{
+			name: "call argument",
+			code: register.Call("a", NewFunc(FuncTypeInline, "", nil, nil, nil).C(NewReturn(nil)).ToValue()),
+			want: "register(\"a\", func() { return })",
+		},
+		{
+			name: "invoke immediately",
+			code: NewFunc(FuncTypeInline, "", nil, nil, nil).ToValue().Call(),
+			want: "func() { }()",
+		},
+		{
+			name: "struct field",
+			code: server,
+			want: "type Server struct {\nOnClose func() error\n}\n",
+		},
+		{
+			name: "struct field literal",
+			code: server.LiteralKV("OnClose", NewFunc(FuncTypeInline, "", nil, nil, []Arg{errRet}).C(NewReturn(NewValueNil())).ToValue()),
+			want: "Server{OnClose: func() error { return nil}}",
+		},
+		{
+			name: "signature type",
+			code: NewVar(NewSpec([]string{"f"}, handler.Signature(), nil)),
+			want: "var f func(ctx context.Context) error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosureSignature(t *testing.T) {
+	f := NewFunc(FuncTypeDefault, "", nil,
+		[]Arg{NewArg("name", NewTypeI(""), false), NewArg("vs", NewTypeI(0), true)},
+		[]Arg{NewArg("n", NewTypeI(0), false), NewArg("err", NewTypeName("error"), false)})
+	v := f.ToValue()
+	if got, want := v.Type().String(), "func(name string, vs ...int) (n int, err error)"; got != want {
+		t.Errorf("Type().String() = %q, want %q", got, want)
+	}
+	if !v.Type().IsFunc() {
+		t.Error("Type().IsFunc() = false, want true")
+	}
+}
@@ -133,7 +133,7 @@ func pkgFunc(funcI interface{}) Value {
 	for i := 0; i < typ.NumOut(); i++ {
 		outs = append(outs, NewType(typ.Out(i)))
 	}
-	return NewValueFunc(valueName, NewTypeDetail(pkg, funcName), ins, outs)
+	return NewValueFunc(valueName, newTypeNode(pkg, funcName, 0, nil), ins, outs)
 }
 
 // NewForRange func
@@ -147,6 +147,33 @@ func NewForRange(autoSet bool, typ FuncType, toValues Value, value Value, cs ...
 	}
 }
 
+// NewFor func, init, cond and post can be nil
+func NewFor(typ FuncType, init Value, cond Value, post Value, cs ...Codable) For {
+	return &tFor{
+		Type:  typ,
+		Init:  init,
+		Cond:  cond,
+		Post:  post,
+		Codes: cs,
+	}
+}
+
+// NewLabel func
+func NewLabel(name string, c Codable) Label {
+	return &tLabel{
+		Name: name,
+		Code: c,
+	}
+}
+
+// NewBranch func, label can be empty
+func NewBranch(kind BranchKind, label string) Branch {
+	return &tBranch{
+		Kind:  kind,
+		Label: label,
+	}
+}
+
 // NewPtrChecker func
 func NewPtrChecker(ifNotNil bool, checkerValue ...Value) PtrChecker {
 	return &tPtrChecker{
@@ -297,6 +324,26 @@ func NewValueI(i interface{}) Value {
 	}
 }
 
+// NewValueLiteral func, the runtime value will be encoded as go literal code,
+// like `map[string]time.Duration{"a": 5 * time.Second}`
+func NewValueLiteral(i interface{}) Value {
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		IType:        NewType(reflect.TypeOf(i)),
+		IValue:       i,
+		Action:       ValueActionEncode,
+		Left:         nil,
+		Right:        nil,
+		Name:         "",
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
 // NewValueNil func
 func NewValueNil() Value {
 	return NewValueNameI("nil", nil)
@@ -320,6 +367,15 @@ func NewTypeI(i interface{}) Type {
 		kind:        0,
 		funcs:       nil,
 		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
 	}
 }
 
@@ -328,27 +384,200 @@ func NewTypeName(name string) Type {
 	return NewTypeDetail("", name)
 }
 
-// NewTypeDetail func
+// NewTypeDetail func, parse a go type expression, the unqualified named type is in pkg,
+// like `map[string][]*github.com/acme/x.Foo`, `[4]byte`, `chan<- error` or `func(context.Context) error`,
+// the expression can't be parsed is kept as an opaque name
 func NewTypeDetail(pkg string, name string) Type {
+	if res, err := parseTypeExpr(pkg, name); err == nil {
+		return res
+	}
+	return newOpaqueType(pkg, name)
+}
+
+// newOpaqueType the type kept by the raw string, only the leading `*` and `[]` are split
+func newOpaqueType(pkg string, name string) Type {
 	var next Type
 	if strings.HasPrefix(name, "*") && name != "*" {
-		next = NewTypeDetail(pkg, name[1:])
+		next = newOpaqueType(pkg, name[1:])
 		name = name[:1]
 	} else if strings.HasPrefix(name, "[]") && name != "[]" {
-		next = NewTypeDetail(pkg, name[2:])
+		next = newOpaqueType(pkg, name[2:])
 		name = name[:2]
 	}
+	return newTypeNode(pkg, name, 0, next)
+}
+
+// newTypeNode build a symbolic type node without reflect type, like `*` or `Foo` in `*Foo`
+func newTypeNode(pkg string, str string, kind reflect.Kind, next Type) Type {
+	inReference := false
+	if next != nil {
+		inReference = next.InReference()
+	}
 	return &tType{
 		TNoteCode:   TNoteCode{nil},
-		Str:         name,
+		Str:         str,
 		Pkg:         pkg,
 		Type:        nil,
 		Named:       "",
 		Next:        next,
+		inReference: inReference,
+		kind:        kind,
+		funcs:       nil,
+		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+// NewTypeMap func, like `map[string]int`
+func NewTypeMap(key Type, elem Type) Type {
+	if !key.IsNil() && !elem.IsNil() {
+		return NewType(reflect.MapOf(key.RefType(), elem.RefType()))
+	}
+	res := newTypeNode("", "map["+key.String()+"]", reflect.Map, elem).(*tType)
+	res.mapKey = key
+	return res
+}
+
+// NewTypeArray func, like `[4]byte`
+func NewTypeArray(length int, elem Type) Type {
+	if !elem.IsNil() {
+		return NewType(reflect.ArrayOf(length, elem.RefType()))
+	}
+	return newTypeNode("", fmt.Sprintf("[%d]", length), reflect.Array, elem)
+}
+
+// NewTypeChan func, like `chan int`, `<-chan int` or `chan<- int`
+func NewTypeChan(elem Type, dir reflect.ChanDir) Type {
+	if !elem.IsNil() {
+		return NewType(reflect.ChanOf(dir, elem.RefType()))
+	}
+	return &tType{
+		TNoteCode:   TNoteCode{nil},
+		Str:         chanDirPrefix(dir),
+		Pkg:         "",
+		Type:        nil,
+		Named:       "",
+		Next:        elem,
+		inReference: elem.InReference(),
+		kind:        reflect.Chan,
+		funcs:       nil,
+		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+// NewTypeFunc func, build a func signature type like `func(context.Context, string) (int, error)`
+func NewTypeFunc(args []Arg, returns []Arg) Type {
+	for i, v := range args {
+		if v.GetVariableLength() && i != len(args)-1 {
+			panic("only the last arg of func can be variadic")
+		}
+	}
+	return &tType{
+		TNoteCode:   TNoteCode{nil},
+		Str:         "",
+		Pkg:         "",
+		Type:        nil,
+		Named:       "",
+		Next:        nil,
 		inReference: false,
-		kind:        0,
+		kind:        reflect.Func,
+		funcs:       nil,
+		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    args,
+		funcReturns: returns,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+// NewTypeUnion func, build a type set union like `~int | ~string`
+func NewTypeUnion(terms ...Type) Type {
+	return &tType{
+		TNoteCode:   TNoteCode{nil},
+		Str:         "",
+		Pkg:         "",
+		Type:        nil,
+		Named:       "",
+		Next:        nil,
+		inReference: false,
+		kind:        reflect.Interface,
+		funcs:       nil,
+		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       terms,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+// NewTypeApprox func, build a approximation constraint like `~int`
+func NewTypeApprox(t Type) Type {
+	return &tType{
+		TNoteCode:   TNoteCode{nil},
+		Str:         "~",
+		Pkg:         "",
+		Type:        nil,
+		Named:       "",
+		Next:        t,
+		inReference: false,
+		kind:        reflect.Interface,
 		funcs:       nil,
 		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+// NewTypeParam func, like `T any` in `func Foo[T any]()`
+func NewTypeParam(name string, constraint Type) Arg {
+	return NewArg(name, constraint, false)
+}
+
+// NewGo func
+func NewGo(v Value) Go {
+	return &tGo{
+		Value: v,
+	}
+}
+
+// NewDefer func
+func NewDefer(v Value) Defer {
+	return &tDefer{
+		Value: v,
 	}
 }
 
@@ -362,6 +591,31 @@ func NewIf(v Value, cs ...Codable) If {
 	}
 }
 
+// NewSwitch func
+func NewSwitch(init Value, v Value) Switch {
+	return &tSwitch{
+		Init:  init,
+		Value: v,
+		Cases: nil,
+	}
+}
+
+// NewTypeSwitch func, like `switch bind := v.(type)`, bind can be nil
+func NewTypeSwitch(bind Value, v Value) TypeSwitch {
+	return &tTypeSwitch{
+		Bind:  bind,
+		Value: v,
+		Cases: nil,
+	}
+}
+
+// NewSelect func
+func NewSelect() Select {
+	return &tSelect{
+		Cases: nil,
+	}
+}
+
 // NewArgI func
 func NewArgI(name string, i interface{}) Arg {
 	return &tArg{
@@ -393,18 +647,89 @@ func NewReceiver(name string, typ Type) Receiver {
 // NewFunc func
 func NewFunc(typ FuncType, name string, receiver Receiver, args []Arg, returns []Arg, notes ...Note) Func {
 	f := &tFunc{
-		TNoteCode: TNoteCode{nil},
-		Type:      typ,
-		Name:      name,
-		Receiver:  receiver,
-		Args:      args,
-		Returns:   returns,
-		Codes:     nil,
+		TNoteCode:  TNoteCode{nil},
+		Type:       typ,
+		Name:       name,
+		Receiver:   receiver,
+		Args:       args,
+		Returns:    returns,
+		Codes:      nil,
+		TypeParams: nil,
 	}
 	f.SetNotes(notes)
 	return f
 }
 
+// NewLiteral func, build a composite literal like `T{a, b}`, a pointer type builds `&T{}`
+func NewLiteral(t Type, elems ...Value) Value {
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		IType:        t,
+		Action:       ValueActionLiteral,
+		Values:       elems,
+		Left:         nil,
+		Right:        nil,
+		Name:         "",
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+// NewKeyValue func, build a keyed element of composite literal like `A: x`
+func NewKeyValue(key interface{}, value interface{}) Value {
+	v := MustToValue("", value)
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Left:         MustToValue("", key),
+		Action:       ValueActionKeyValue,
+		Right:        v,
+		IType:        v.Type(),
+		Name:         "",
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+// NewSpec func
+func NewSpec(names []string, typ Type, values []Value, notes ...Note) Spec {
+	s := &tSpec{
+		TNoteCode: TNoteCode{nil},
+		Names:     names,
+		Type:      typ,
+		Values:    values,
+	}
+	s.SetNotes(notes)
+	return s
+}
+
+// NewDecl func
+func NewDecl(kind DeclKind, specs ...Spec) Decl {
+	return &tDecl{
+		TNoteCode: TNoteCode{nil},
+		Kind:      kind,
+		Specs:     specs,
+	}
+}
+
+// NewConst func
+func NewConst(specs ...Spec) Decl {
+	return NewDecl(DeclKindConst, specs...)
+}
+
+// NewVar func
+func NewVar(specs ...Spec) Decl {
+	return NewDecl(DeclKindVar, specs...)
+}
+
 // NewStruct func
 func NewStruct(name string, fs []Field) Type {
 	return &tType{
@@ -418,6 +743,15 @@ func NewStruct(name string, fs []Field) Type {
 		Next:        nil,
 		kind:        reflect.Struct,
 		funcs:       nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
 	}
 }
 
@@ -434,6 +768,41 @@ func NewInterface(name string, fs []Func) Type {
 		Next:        nil,
 		kind:        reflect.Interface,
 		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+// NewInterfaceEmbeds func, like `type ReadCloser interface { io.Reader; Close() error }`, the embeds can be
+// interfaces or type set elements like `~int | ~string`
+func NewInterfaceEmbeds(name string, embeds []Type, fs []Func) Type {
+	return &tType{
+		TNoteCode:   TNoteCode{nil},
+		Named:       name,
+		funcs:       fs,
+		inReference: false,
+		Str:         "",
+		Pkg:         "",
+		Type:        nil,
+		Next:        nil,
+		kind:        reflect.Interface,
+		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      embeds,
 	}
 }
 
@@ -444,9 +813,30 @@ func NewField(name string, typ Type, tag string) Field {
 		Type:      typ,
 		ReName:    name,
 		Tag:       tag,
+		Embedded:  false,
 	}
 }
 
+// NewEmbeddedField func, like `Base` or `*pkg.Base` in `struct { Base }`
+func NewEmbeddedField(typ Type, tag string) Field {
+	return &tField{
+		TNoteCode: TNoteCode{nil},
+		Type:      typ,
+		ReName:    embeddedFieldName(typ),
+		Tag:       tag,
+		Embedded:  true,
+	}
+}
+
+// NewStructTag func, like `json:"name,omitempty"` without backticks, panic if the tag is malformed
+func NewStructTag(raw string) StructTag {
+	res, err := ParseStructTag(raw)
+	if err != nil {
+		panic(err.Error())
+	}
+	return res
+}
+
 // NewType func
 func NewType(t reflect.Type) Type {
 	return &tType{
@@ -460,6 +850,15 @@ func NewType(t reflect.Type) Type {
 		kind:        0,
 		funcs:       nil,
 		fields:      nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
 	}
 }
 
@@ -0,0 +1,99 @@
+package gocoder
+
+// DeclKind type
+type DeclKind string
+
+// DeclKind type
+const (
+	DeclKindConst DeclKind = "const"
+	DeclKindVar   DeclKind = "var"
+)
+
+// Spec type, a single line of a const or var declaration, like `StatusA Status = iota`
+type Spec interface {
+	Codable
+	NoteCode
+
+	GetNames() []string
+	GetType() Type
+	GetValues() []Value
+
+	InterfaceForSpec() bool
+}
+
+var _ Spec = (*tSpec)(nil)
+
+type tSpec struct {
+	TNoteCode
+	Names  []string
+	Type   Type
+	Values []Value
+}
+
+func (t *tSpec) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tSpec) GetNames() []string {
+	return t.Names
+}
+
+func (t *tSpec) GetType() Type {
+	return t.Type
+}
+
+func (t *tSpec) GetValues() []Value {
+	return t.Values
+}
+
+func (t *tSpec) InterfaceForSpec() bool {
+	return true
+}
+
+// Decl type, like `const A = 1` or `var ( ... )`
+type Decl interface {
+	Codable
+	NoteCode
+
+	GetKind() DeclKind
+	GetSpecs() []Spec
+	IsGrouped() bool
+
+	AddSpecs(specs ...Spec) Decl
+
+	InterfaceForDecl() bool
+}
+
+var _ Decl = (*tDecl)(nil)
+
+type tDecl struct {
+	TNoteCode
+	Kind  DeclKind
+	Specs []Spec
+}
+
+func (t *tDecl) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tDecl) GetKind() DeclKind {
+	return t.Kind
+}
+
+func (t *tDecl) GetSpecs() []Spec {
+	return t.Specs
+}
+
+// IsGrouped the spec notes can only be written in a grouped declaration
+func (t *tDecl) IsGrouped() bool {
+	return len(t.Specs) != 1 || len(t.Specs[0].Notes()) > 0
+}
+
+func (t *tDecl) AddSpecs(specs ...Spec) Decl {
+	t.Specs = append(t.Specs, specs...)
+	return t
+}
+
+func (t *tDecl) InterfaceForDecl() bool {
+	return true
+}
@@ -0,0 +1,81 @@
+package gocoder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeclToCode(t *testing.T) {
+	status := NewTypeName("Status")
+	iota := NewValueNameI("iota", int(0))
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "single const",
+			code: NewConst(NewSpec([]string{"Max"}, nil, []Value{NewValueI(10)})),
+			want: "const Max = 10",
+		},
+		{
+			name: "single typed var",
+			code: NewVar(NewSpec([]string{"a", "b"}, NewTypeI(int(0)), nil)),
+			want: "var a, b int",
+		},
+		{
+			name: "iota enum",
+			code: NewConst(
+				NewSpec([]string{"StatusA"}, status, []Value{iota}, NewNote("the first status", NoteKindLine)),
+				NewSpec([]string{"StatusB"}, nil, nil),
+				NewSpec([]string{"StatusC"}, nil, nil),
+			),
+			want: "const (\n\t// the first status\n\tStatusA Status = iota\n\tStatusB\n\tStatusC\n)",
+		},
+		{
+			name: "var block",
+			code: NewVar(
+				NewSpec([]string{"x"}, nil, []Value{iota.Add(1)}),
+				NewSpec([]string{"y", "z"}, nil, []Value{NewValueI("y"), NewValueI("z")}),
+			),
+			want: "var (\n\tx = iota + 1\n\ty, z = \"y\", \"z\"\n)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnumWithStringToCode(t *testing.T) {
+	status := NewTypeName("Status")
+	s := NewValue("s", status)
+	decl := NewConst(
+		NewSpec([]string{"StatusA"}, status, []Value{NewValueNameI("iota", int(0))}),
+		NewSpec([]string{"StatusB"}, nil, nil),
+	)
+	decl.AddNotes(NewNote("Status values", NoteKindLine))
+	str := NewFunc(FuncTypeDefault, "String", NewReceiver("s", status), nil, []Arg{NewArg("", NewTypeI(""), false)}).C(
+		NewSwitch(nil, s).
+			Case(NewValue("StatusA", nil), NewReturn(NewValueI("A"))).
+			Case(NewValue("StatusB", nil), NewReturn(NewValueI("B"))),
+		NewReturn(NewValueI("")),
+	)
+	filename := filepath.Join(t.TempDir(), "status.go")
+	if err := WriteToFile(filename, NewCode().C(decl, str), NewToCodeOpt().PkgName("status")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package status\n\n// Status values\nconst (\n\tStatusA Status = iota\n\tStatusB\n)\n\n" +
+		"func (s Status) String() string {\n\tswitch s {\n\tcase StatusA:\n\t\treturn \"A\"\n\tcase StatusB:\n\t\treturn \"B\"\n\t}\n\treturn \"\"\n}\n"
+	if string(got) != want {
+		t.Errorf("WriteToFile() = %q, want %q", got, want)
+	}
+}
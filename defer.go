@@ -0,0 +1,27 @@
+package gocoder
+
+// Defer type, like `defer f()`
+type Defer interface {
+	Codable
+
+	GetValue() Value
+	InterfaceForDefer() bool
+}
+
+var _ Defer = (*tDefer)(nil)
+
+type tDefer struct {
+	Value Value
+}
+
+func (t *tDefer) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tDefer) GetValue() Value {
+	return t.Value
+}
+
+func (t *tDefer) InterfaceForDefer() bool {
+	return true
+}
@@ -0,0 +1,91 @@
+package gocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testEmbedBase struct {
+	ID   string
+	Name string
+}
+
+type testEmbedDoc struct {
+	testEmbedBase
+	*testEmbedBox
+	Name string
+}
+
+type testEmbedBox struct {
+	Box int
+}
+
+func TestEmbeddedField(t *testing.T) {
+	base := NewStruct("Base", []Field{NewField("ID", NewTypeI(""), "")})
+	other := NewTypeDetail("github.com/acme/x", "Other")
+	foo := NewStruct("Foo", []Field{
+		NewEmbeddedField(base, ""),
+		NewEmbeddedField(other.TackPtr(), `json:"o"`),
+		NewField("Name", NewTypeI(""), ""),
+	})
+	if f := foo.FieldByName("Other"); f == nil || !f.IsEmbedded() {
+		t.Fatalf("FieldByName(Other) want an embedded field")
+	}
+	want := "type Foo struct {\nBase\n*x.Other `json:\"o\"`\nName string\n}\n"
+	if got := ToCode(foo); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+	if f := foo.FieldByName("ID"); f == nil || f.GetType().Kind() != reflect.String {
+		t.Errorf("FieldByName(ID) want the promoted field of Base")
+	}
+}
+
+func TestReflectFlattenFields(t *testing.T) {
+	doc := NewTypeI(testEmbedDoc{})
+	if !doc.Field(0).IsEmbedded() || doc.Field(2).IsEmbedded() {
+		t.Errorf("IsEmbedded() of Field(0), Field(2) want true, false")
+	}
+	names := make([]string, 0)
+	for _, f := range doc.FlattenFields() {
+		names = append(names, f.GetName())
+	}
+	if want := []string{"Name", "ID", "Box"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("FlattenFields() = %v, want %v", names, want)
+	}
+}
+
+type testEmbedLeft struct {
+	testEmbedBase
+}
+
+type testEmbedRight struct {
+	testEmbedBase
+}
+
+type testEmbedBoth struct {
+	testEmbedLeft
+	testEmbedRight
+	Box int
+}
+
+func TestFlattenFieldsAmbiguous(t *testing.T) {
+	base := NewStruct("Base", []Field{NewField("ID", NewTypeI(""), "")})
+	left := NewStruct("Left", []Field{NewEmbeddedField(base, "")})
+	right := NewStruct("Right", []Field{NewEmbeddedField(base.TackPtr(), "")})
+	foo := NewStruct("Foo", []Field{NewEmbeddedField(left, ""), NewEmbeddedField(right, ""), NewField("Name", NewTypeI(""), "")})
+	// Base is embedded twice at the same depth, like `Foo.ID` is an ambiguous selector in Go
+	if fs := foo.FlattenFields(); len(fs) != 1 || fs[0].GetName() != "Name" {
+		t.Errorf("FlattenFields() = %v, want only Name", fs)
+	}
+	if f := foo.FieldByName("ID"); f != nil {
+		t.Errorf("FieldByName(ID) = %v, want nil", f)
+	}
+
+	names := make([]string, 0)
+	for _, f := range NewTypeI(testEmbedBoth{}).FlattenFields() {
+		names = append(names, f.GetName())
+	}
+	if want := []string{"Box"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("reflect FlattenFields() = %v, want %v", names, want)
+	}
+}
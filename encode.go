@@ -0,0 +1,367 @@
+package gocoder
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bytesType    = reflect.TypeOf([]byte(nil))
+)
+
+// the types of untyped constant default to, these values can be written without conversion
+var literalDefaultTypes = map[reflect.Type]bool{
+	reflect.TypeOf(false):         true,
+	reflect.TypeOf(int(0)):        true,
+	reflect.TypeOf(float64(0)):    true,
+	reflect.TypeOf(complex128(0)): true,
+	reflect.TypeOf(""):            true,
+}
+
+var durationUnits = []struct {
+	d    time.Duration
+	name string
+}{
+	{time.Hour, "Hour"},
+	{time.Minute, "Minute"},
+	{time.Second, "Second"},
+	{time.Millisecond, "Millisecond"},
+	{time.Microsecond, "Microsecond"},
+}
+
+// literalEncoder encode a runtime value to go literal code, the used packages will be added to the pkg tool
+type literalEncoder struct {
+	tool    PkgTool
+	toPkg   string
+	visited map[uintptr]bool
+}
+
+// encodeLiteral get the go literal code of a runtime value, like `[]*pkg.Foo{&pkg.Foo{A: 1}}`
+func encodeLiteral(i interface{}, tool PkgTool, toPkg string) string {
+	e := &literalEncoder{
+		tool:    tool,
+		toPkg:   toPkg,
+		visited: make(map[uintptr]bool),
+	}
+	return e.encode(reflect.ValueOf(i), false)
+}
+
+// isCompositeValue the value can't be written by fmt, like struct, map or slice
+func isCompositeValue(i interface{}) bool {
+	switch reflect.TypeOf(i).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr:
+		return true
+	}
+	return false
+}
+
+// literalPrecedence the precedence of encoded literal code, like `5 * time.Second` or `-1`
+func literalPrecedence(i interface{}) int {
+	if _, ok := i.(time.Duration); ok {
+		return precedenceMul
+	}
+	return precedenceUnary
+}
+
+// encode typed is true if the type of value can be inferred from context, like the element of a slice literal
+func (e *literalEncoder) encode(rv reflect.Value, typed bool) string {
+	if !rv.IsValid() {
+		return "nil"
+	}
+	rt := rv.Type()
+	switch rt {
+	case timeType:
+		return e.encodeTime(rv.Interface().(time.Time))
+	case durationType:
+		return e.encodeDuration(time.Duration(rv.Int()))
+	}
+	switch rt.Kind() {
+	case reflect.Bool:
+		return e.convert(rt, strconv.FormatBool(rv.Bool()), typed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.convert(rt, strconv.FormatInt(rv.Int(), 10), typed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.convert(rt, strconv.FormatUint(rv.Uint(), 10), typed)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// math.NaN() and math.Inf() are float64, can't be assigned to other float types
+			return e.convert(rt, e.encodeSpecialFloat(f), rt.Kind() == reflect.Float64 && rt.PkgPath() == "")
+		}
+		return e.convert(rt, formatFloatLiteral(f, rt.Bits()), typed)
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		str := "complex(" + formatFloatLiteral(real(c), 64) + ", " + formatFloatLiteral(imag(c), 64) + ")"
+		return e.convert(rt, str, typed)
+	case reflect.String:
+		return e.convert(rt, strconv.Quote(rv.String()), typed)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return "nil"
+		}
+		return e.encode(rv.Elem(), false)
+	case reflect.Ptr:
+		return e.encodePtr(rv, typed)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return e.nilOf(rt, typed)
+		}
+		if rt.Elem().Kind() == reflect.Uint8 && rt.Elem().PkgPath() == "" {
+			return e.encodeBytes(rv)
+		}
+		return e.typeCode(rt) + "{" + e.encodeElems(rv) + "}"
+	case reflect.Array:
+		return e.typeCode(rt) + "{" + e.encodeElems(rv) + "}"
+	case reflect.Map:
+		if rv.IsNil() {
+			return e.nilOf(rt, typed)
+		}
+		return e.encodeMap(rv)
+	case reflect.Struct:
+		return e.encodeStruct(rv)
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if rv.IsNil() {
+			return e.nilOf(rt, typed)
+		}
+	}
+	panic(fmt.Sprintf("encode literal unsupported type: %s", rt.String()))
+}
+
+// convert the basic literal to target type if need, like `int8(1)`
+func (e *literalEncoder) convert(rt reflect.Type, str string, typed bool) string {
+	if typed || literalDefaultTypes[rt] {
+		return str
+	}
+	return e.typeCode(rt) + "(" + str + ")"
+}
+
+func (e *literalEncoder) nilOf(rt reflect.Type, typed bool) string {
+	if typed {
+		return "nil"
+	}
+	return "(" + e.typeCode(rt) + ")(nil)"
+}
+
+func (e *literalEncoder) encodeSpecialFloat(f float64) string {
+	alias := e.tool.PkgAlias("math")
+	switch {
+	case math.IsNaN(f):
+		return alias + ".NaN()"
+	case math.IsInf(f, 1):
+		return alias + ".Inf(1)"
+	default:
+		return alias + ".Inf(-1)"
+	}
+}
+
+func (e *literalEncoder) encodePtr(rv reflect.Value, typed bool) string {
+	rt := rv.Type()
+	if rv.IsNil() {
+		return e.nilOf(rt, typed)
+	}
+	if e.visited[rv.Pointer()] {
+		panic(fmt.Sprintf("encode literal pointer cycle of type: %s", rt.String()))
+	}
+	e.visited[rv.Pointer()] = true
+	defer delete(e.visited, rv.Pointer())
+	elem := rv.Elem()
+	if elem.Kind() == reflect.Struct && elem.Type() != timeType {
+		return "&" + e.encode(elem, false)
+	}
+	// like `func() *int { v := 1; return &v }()`
+	return "func() " + e.typeCode(rt) + " { v := " + e.encode(elem, false) + "; return &v }()"
+}
+
+func (e *literalEncoder) encodeBytes(rv reflect.Value) string {
+	bs := rv.Bytes()
+	typ := e.typeCode(rv.Type())
+	if utf8.Valid(bs) {
+		return typ + "(" + strconv.Quote(string(bs)) + ")"
+	}
+	strs := make([]string, len(bs))
+	for i, b := range bs {
+		strs[i] = fmt.Sprintf("0x%02x", b)
+	}
+	return typ + "{" + strings.Join(strs, ", ") + "}"
+}
+
+func (e *literalEncoder) encodeElems(rv reflect.Value) string {
+	strs := make([]string, rv.Len())
+	for i := range strs {
+		strs[i] = e.encode(rv.Index(i), true)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func (e *literalEncoder) encodeMap(rv reflect.Value) string {
+	keys := rv.MapKeys()
+	keyStrs := make([]string, len(keys))
+	for i, k := range keys {
+		keyStrs[i] = e.encode(k, true)
+	}
+	index := make([]int, len(keys))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		return literalKeyLess(keys[index[i]], keys[index[j]], keyStrs[index[i]], keyStrs[index[j]])
+	})
+	strs := make([]string, len(keys))
+	for i, v := range index {
+		strs[i] = keyStrs[v] + ": " + e.encode(rv.MapIndex(keys[v]), true)
+	}
+	return e.typeCode(rv.Type()) + "{" + strings.Join(strs, ", ") + "}"
+}
+
+// literalKeyLess sort map keys by value if it's a basic type, or by literal code
+func literalKeyLess(a, b reflect.Value, aStr, bStr string) bool {
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+	}
+	return aStr < bStr
+}
+
+// encodeStruct only the exported and non-zero fields will be encoded
+func (e *literalEncoder) encodeStruct(rv reflect.Value) string {
+	rt := rv.Type()
+	strs := make([]string, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" || rv.Field(i).IsZero() {
+			continue
+		}
+		strs = append(strs, f.Name+": "+e.encode(rv.Field(i), true))
+	}
+	return e.typeCode(rt) + "{" + strings.Join(strs, ", ") + "}"
+}
+
+func (e *literalEncoder) encodeTime(t time.Time) string {
+	alias := e.tool.PkgAlias("time")
+	if t.IsZero() {
+		return alias + ".Time{}"
+	}
+	var loc string
+	switch t.Location() {
+	case time.UTC:
+		loc = alias + ".UTC"
+	case time.Local:
+		loc = alias + ".Local"
+	default:
+		name, offset := t.Zone()
+		loc = fmt.Sprintf("%s.FixedZone(%q, %d)", alias, name, offset)
+	}
+	return fmt.Sprintf("%s.Date(%d, %s.%s, %d, %d, %d, %d, %d, %s)",
+		alias, t.Year(), alias, t.Month().String(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// encodeDuration like `1500 * time.Millisecond`
+func (e *literalEncoder) encodeDuration(d time.Duration) string {
+	alias := e.tool.PkgAlias("time")
+	if d != 0 {
+		for _, unit := range durationUnits {
+			if d%unit.d != 0 {
+				continue
+			}
+			switch n := d / unit.d; n {
+			case 1:
+				return alias + "." + unit.name
+			case -1:
+				return "-" + alias + "." + unit.name
+			default:
+				return fmt.Sprintf("%d * %s.%s", n, alias, unit.name)
+			}
+		}
+	}
+	return fmt.Sprintf("%s.Duration(%d)", alias, int64(d))
+}
+
+// typeCode get the type code in reference, like `map[string]*pkg.Foo`
+func (e *literalEncoder) typeCode(rt reflect.Type) string {
+	if rt == bytesType {
+		return "[]byte"
+	}
+	if rt.Name() != "" {
+		if rt.PkgPath() == "" {
+			return rt.Name()
+		}
+		if rt.PkgPath() == e.toPkg {
+			return rt.Name()
+		}
+		if alias := e.tool.PkgAlias(rt.PkgPath()); alias != "" {
+			return alias + "." + rt.Name()
+		}
+		return rt.Name()
+	}
+	switch rt.Kind() {
+	case reflect.Ptr:
+		return "*" + e.typeCode(rt.Elem())
+	case reflect.Slice:
+		return "[]" + e.typeCode(rt.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]", rt.Len()) + e.typeCode(rt.Elem())
+	case reflect.Map:
+		return "map[" + e.typeCode(rt.Key()) + "]" + e.typeCode(rt.Elem())
+	case reflect.Chan:
+		return chanElemString(chanDirPrefix(rt.ChanDir()), e.typeCode(rt.Elem()))
+	case reflect.Struct:
+		return e.structTypeCode(rt)
+	case reflect.Func:
+		args, returns := reflectSignatureArgs(rt)
+		return signatureString(args, returns, func(t Type) string {
+			return e.typeCode(t.RefType())
+		})
+	}
+	return rt.String()
+}
+
+// structTypeCode the anonymous struct type, like `struct{ T time.Time }`
+func (e *literalEncoder) structTypeCode(rt reflect.Type) string {
+	if rt.NumField() == 0 {
+		return "struct{}"
+	}
+	strs := make([]string, rt.NumField())
+	for i := range strs {
+		f := rt.Field(i)
+		str := e.typeCode(f.Type)
+		if !f.Anonymous {
+			str = f.Name + " " + str
+		}
+		if f.Tag != "" {
+			if strings.Contains(string(f.Tag), "`") {
+				str += " " + strconv.Quote(string(f.Tag))
+			} else {
+				str += " `" + string(f.Tag) + "`"
+			}
+		}
+		strs[i] = str
+	}
+	return "struct{ " + strings.Join(strs, "; ") + " }"
+}
+
+// formatFloatLiteral format float as a float literal, like `1.0` rather than `1`
+func formatFloatLiteral(f float64, bits int) string {
+	str := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(str, ".eE") {
+		str += ".0"
+	}
+	return str
+}
@@ -0,0 +1,140 @@
+package gocoder
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+type testEncodeItem struct {
+	Name    string
+	Count   int8
+	Tags    []string
+	Next    *testEncodeItem
+	Timeout time.Duration
+	private int
+}
+
+type testEncodeKind string
+
+func TestEncodeLiteral(t *testing.T) {
+	n := 3
+	tests := []struct {
+		name string
+		i    interface{}
+		want string
+	}{
+		{name: "int", i: 1, want: "1"},
+		{name: "int8", i: int8(-1), want: "int8(-1)"},
+		{name: "float", i: 2.0, want: "2.0"},
+		{name: "float32", i: float32(1.5), want: "float32(1.5)"},
+		{name: "nan", i: float32(math.NaN()), want: "float32(math.NaN())"},
+		{name: "string", i: "a\"b\n", want: `"a\"b\n"`},
+		{name: "named string", i: testEncodeKind("k"), want: `gocoder.testEncodeKind("k")`},
+		{name: "bytes", i: []byte("abc"), want: `[]byte("abc")`},
+		{name: "binary bytes", i: []byte{0xff, 0x01}, want: "[]byte{0xff, 0x01}"},
+		{name: "nil slice", i: []int(nil), want: "([]int)(nil)"},
+		{name: "int ptr", i: &n, want: "func() *int { v := 3; return &v }()"},
+		{name: "duration", i: 1500 * time.Millisecond, want: "1500 * time.Millisecond"},
+		{name: "duration unit", i: -time.Hour, want: "-time.Hour"},
+		{name: "time", i: time.Date(2021, time.March, 4, 5, 6, 7, 8, time.UTC), want: "time.Date(2021, time.March, 4, 5, 6, 7, 8, time.UTC)"},
+		{name: "zero time", i: time.Time{}, want: "time.Time{}"},
+		{
+			name: "sorted map",
+			i:    map[int]string{3: "c", 1: "a", 2: "b"},
+			want: `map[int]string{1: "a", 2: "b", 3: "c"}`,
+		},
+		{
+			name: "interface slice",
+			i:    []interface{}{1, int64(2), "c", nil, []int{4}},
+			want: `[]interface {}{1, int64(2), "c", nil, []int{4}}`,
+		},
+		{
+			name: "nested struct",
+			i: []*testEncodeItem{{
+				Name:    "a",
+				Count:   2,
+				Tags:    []string{"x"},
+				Next:    &testEncodeItem{Name: "b", Tags: nil, Next: nil, Count: 0, Timeout: 0, private: 1},
+				Timeout: time.Second,
+				private: 1,
+			}},
+			want: `[]*gocoder.testEncodeItem{&gocoder.testEncodeItem{Name: "a", Count: 2, Tags: []string{"x"}, Next: &gocoder.testEncodeItem{Name: "b"}, Timeout: time.Second}}`,
+		},
+		{
+			name: "chan of recv only chan",
+			i:    []chan (<-chan int){nil},
+			want: "[]chan (<-chan int){nil}",
+		},
+		{
+			name: "array",
+			i:    [2]time.Duration{time.Minute, 0},
+			want: "[2]time.Duration{time.Minute, time.Duration(0)}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(NewValueLiteral(tt.i)); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeLiteralImports(t *testing.T) {
+	pkgTool := NewDefaultPkgTool()
+	ToCode(NewValueLiteral(map[string]interface{}{"a": time.Second, "b": math.Inf(1)}), NewToCodeOpt().PkgTool(pkgTool))
+	for _, pkg := range []string{"time", "math"} {
+		if _, ok := pkgTool.PkgAliasMap()[pkg]; !ok {
+			t.Errorf("PkgAliasMap() = %v, want %s imported", pkgTool.PkgAliasMap(), pkg)
+		}
+	}
+}
+
+func TestEncodeLiteralAnonymousTypeImports(t *testing.T) {
+	tests := []struct {
+		name string
+		i    interface{}
+		want string
+	}{
+		{
+			name: "struct",
+			i:    []struct{ T time.Time }{{T: time.Time{}}},
+			want: "[]struct{ T time.Time }{struct{ T time.Time }{}}",
+		},
+		{
+			name: "struct tag",
+			i: struct {
+				D time.Duration `json:"d"`
+			}{D: 0},
+			want: "struct{ D time.Duration `json:\"d\"` }{}",
+		},
+		{
+			name: "func",
+			i:    map[string]func(time.Duration) error{"a": nil},
+			want: `map[string]func(time.Duration) error{"a": nil}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgTool := NewDefaultPkgTool()
+			if got := ToCode(NewValueLiteral(tt.i), NewToCodeOpt().PkgTool(pkgTool)); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+			if _, ok := pkgTool.PkgAliasMap()["time"]; !ok {
+				t.Errorf("PkgAliasMap() = %v, want time imported", pkgTool.PkgAliasMap())
+			}
+		})
+	}
+}
+
+func TestEncodeLiteralCycle(t *testing.T) {
+	item := &testEncodeItem{Name: "a", Count: 0, Tags: nil, Next: nil, Timeout: 0, private: 0}
+	item.Next = item
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic for pointer cycle")
+		}
+	}()
+	ToCode(NewValueLiteral(item))
+}
@@ -1,12 +1,21 @@
 package gocoder
 
+import (
+	"reflect"
+	"strings"
+)
+
 type Field interface {
 	Codable
 	NoteCode
 
 	GetTag() string
+	GetStructTag() (StructTag, error) // the parsed copy of tag, write it back by SetStructTag
+	SetTag(string)
+	SetStructTag(StructTag)
 	GetName() string
 	GetType() Type
+	IsEmbedded() bool // like `Base` in `struct { Base }`, the name of field is the type name
 	IsField()
 
 	Clone() Field
@@ -16,9 +25,10 @@ var _ Field = (*tField)(nil)
 
 type tField struct {
 	TNoteCode
-	Type   Type
-	ReName string
-	Tag    string
+	Type     Type
+	ReName   string
+	Tag      string
+	Embedded bool
 }
 
 func (t *tField) Clone() Field {
@@ -27,6 +37,7 @@ func (t *tField) Clone() Field {
 		Type:      t.Type,
 		ReName:    t.ReName,
 		Tag:       t.Tag,
+		Embedded:  t.Embedded,
 	}
 	if t.Type != nil {
 		res.Type = t.Type.Clone()
@@ -42,6 +53,21 @@ func (t *tField) GetTag() string {
 	return t.Tag
 }
 
+func (t *tField) GetStructTag() (StructTag, error) {
+	return ParseStructTag(t.Tag)
+}
+
+func (t *tField) SetTag(v string) {
+	t.Tag = v
+}
+
+func (t *tField) SetStructTag(v StructTag) {
+	t.Tag = ""
+	if v != nil {
+		t.Tag = v.String()
+	}
+}
+
 func (t *tField) GetName() string {
 	return t.ReName
 }
@@ -50,5 +76,37 @@ func (t *tField) GetType() Type {
 	return t.Type
 }
 
+func (t *tField) IsEmbedded() bool {
+	return t.Embedded
+}
+
 func (t *tField) IsField() {
 }
+
+func newReflectField(f reflect.StructField) Field {
+	return &tField{
+		TNoteCode: TNoteCode{nil},
+		Type:      NewType(f.Type),
+		ReName:    f.Name,
+		Tag:       string(f.Tag),
+		Embedded:  f.Anonymous,
+	}
+}
+
+// embeddedFieldName the name of embedded field is the type name, like `Foo` of `*pkg.Foo` or `Set[int]`
+func embeddedFieldName(t Type) string {
+	if t.IsPtr() {
+		t = t.Elem()
+	}
+	name := t.GetNamed()
+	if name == "" && !t.IsNil() {
+		name = t.RefType().Name()
+	}
+	if name == "" {
+		name = t.GetRowStr()
+	}
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,151 @@
+package gocoder
+
+// For type, like `for init; cond; post {}`, `for cond {}` or `for {}`
+type For interface {
+	Codable
+
+	GetType() FuncType
+	GetInit() Value
+	GetCond() Value
+	GetPost() Value
+	GetCodes() []Codable
+
+	C(cs ...Codable) For
+	ToCode() Code
+
+	InterfaceForFor() bool
+}
+
+var _ For = (*tFor)(nil)
+
+type tFor struct {
+	Type  FuncType
+	Init  Value
+	Cond  Value
+	Post  Value
+	Codes []Codable
+}
+
+func (t *tFor) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tFor) C(cs ...Codable) For {
+	t.Codes = append(t.Codes, cs...)
+	return t
+}
+
+func (t *tFor) GetType() FuncType {
+	return t.Type
+}
+
+func (t *tFor) GetInit() Value {
+	return t.Init
+}
+
+func (t *tFor) GetCond() Value {
+	return t.Cond
+}
+
+func (t *tFor) GetPost() Value {
+	return t.Post
+}
+
+func (t *tFor) GetCodes() []Codable {
+	return t.Codes
+}
+
+func (t *tFor) ToCode() Code {
+	return &tForCode{
+		tFor: t,
+	}
+}
+
+func (t *tFor) InterfaceForFor() bool {
+	return true
+}
+
+type tForCode struct {
+	*tFor
+}
+
+func (t *tForCode) C(codes ...Codable) Code {
+	t.tFor.C(codes...)
+	return t
+}
+
+// Label type, like `Outer:` in front of a statement
+type Label interface {
+	Codable
+
+	GetName() string
+	GetCode() Codable
+
+	InterfaceForLabel() bool
+}
+
+var _ Label = (*tLabel)(nil)
+
+type tLabel struct {
+	Name string
+	Code Codable
+}
+
+func (t *tLabel) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tLabel) GetName() string {
+	return t.Name
+}
+
+func (t *tLabel) GetCode() Codable {
+	return t.Code
+}
+
+func (t *tLabel) InterfaceForLabel() bool {
+	return true
+}
+
+// BranchKind type
+type BranchKind string
+
+// BranchKind type
+const (
+	BranchKindBreak    BranchKind = "break"
+	BranchKindContinue BranchKind = "continue"
+	BranchKindGoto     BranchKind = "goto"
+)
+
+// Branch type, like `break`, `continue Outer` or `goto End`
+type Branch interface {
+	Codable
+
+	GetKind() BranchKind
+	GetLabel() string
+
+	InterfaceForBranch() bool
+}
+
+var _ Branch = (*tBranch)(nil)
+
+type tBranch struct {
+	Kind  BranchKind
+	Label string
+}
+
+func (t *tBranch) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tBranch) GetKind() BranchKind {
+	return t.Kind
+}
+
+func (t *tBranch) GetLabel() string {
+	return t.Label
+}
+
+func (t *tBranch) InterfaceForBranch() bool {
+	return true
+}
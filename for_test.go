@@ -0,0 +1,47 @@
+package gocoder
+
+import (
+	"testing"
+)
+
+func TestForToCode(t *testing.T) {
+	i := NewValueNameI("i", int(0))
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "three clause",
+			code: NewFor(FuncTypeDefault, NewValue("i", nil).AutoSet(0), i.LT(10), i.Inc(), NewBranch(BranchKindContinue, "")),
+			want: "for i := 0; i < 10; i++ {\n\tcontinue\n}",
+		},
+		{
+			name: "cond only",
+			code: NewFor(FuncTypeDefault, nil, i.LT(10), nil, NewBranch(BranchKindBreak, "")),
+			want: "for i < 10 {\n\tbreak\n}",
+		},
+		{
+			name: "infinite",
+			code: NewFor(FuncTypeDefault, nil, nil, nil),
+			want: "for {\n}",
+		},
+		{
+			name: "labeled",
+			code: NewLabel("Outer", NewForRange(true, FuncTypeDefault, nil, NewValueNameI("vs", []int{}), NewBranch(BranchKindBreak, "Outer"))),
+			want: "Outer:\nfor range vs {\n\tbreak Outer\n}",
+		},
+		{
+			name: "goto",
+			code: NewBranch(BranchKindGoto, "End"),
+			want: "goto End",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
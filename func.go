@@ -1,5 +1,13 @@
 package gocoder
 
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
 // Func type
 type Func interface {
 	Codable
@@ -13,10 +21,15 @@ type Func interface {
 	GetReturns() []Arg
 	GetReturnTypes() []Type
 	GetReceiver() Receiver
+	GetTypeParams() []Arg
+	SetTypeParams([]Arg)
 
 	C(...Codable) Func
 	Call(...interface{}) Value
 	ToCode() Code
+	Signature() Type
+	ToValue() Value
+	CallCheckErr(v Value, opts ...*CheckErrOption) Code
 
 	InterfaceForFunc() bool
 }
@@ -40,6 +53,9 @@ type tFunc struct {
 	Args     []Arg
 	Returns  []Arg
 	Receiver Receiver
+
+	// generic, like `T any` in `func Foo[T any]()`
+	TypeParams []Arg
 }
 
 func (t *tFunc) WriteCode(w Writer) {
@@ -70,6 +86,14 @@ func (t *tFunc) GetReceiver() Receiver {
 	return t.Receiver
 }
 
+func (t *tFunc) GetTypeParams() []Arg {
+	return t.TypeParams
+}
+
+func (t *tFunc) SetTypeParams(v []Arg) {
+	t.TypeParams = v
+}
+
 func (t *tFunc) ToCode() Code {
 	return &tFuncCode{
 		tFunc: t,
@@ -116,6 +140,30 @@ func (t *tFunc) Call(argsI ...interface{}) Value {
 	}
 }
 
+// Signature get the func type without receiver, like `func(int) error`
+func (t *tFunc) Signature() Type {
+	return NewTypeFunc(t.Args, t.Returns)
+}
+
+// ToValue use the func as a closure value, like `f := func() {}`
+func (t *tFunc) ToValue() Value {
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Func:         t,
+		IType:        t.Signature(),
+		Action:       ValueActionNone,
+		Left:         nil,
+		Right:        nil,
+		Name:         "",
+		IValue:       nil,
+		Str:          "",
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
 type tFuncCode struct {
 	*tFunc
 }
@@ -124,3 +172,100 @@ func (t *tFuncCode) C(cs ...Codable) Code {
 	t.Codes = append(t.Codes, cs...)
 	return t
 }
+
+// CallCheckErr assign the results of v, which returns `(T..., error)`, and return early with the zero values of
+// the func returns if the error isn't nil, like `n, err := load(id)` and `if err != nil { return 0, err }`.
+// The count of results is inferred by v.Returns(), the results without name are `_`, and the results are assigned
+// by `=` if they are the named results of func
+func (t *tFunc) CallCheckErr(v Value, opts ...*CheckErrOption) Code {
+	opt := MergeCheckErrOpt(opts...)
+	returns := t.GetReturnTypes()
+	if len(returns) == 0 || !isErrorType(returns[len(returns)-1]) {
+		panic(fmt.Sprintf("CallCheckErr func %s last return isn't error", t.Name))
+	}
+
+	count := len(v.Returns())
+	if count == 0 {
+		// the results of call are unknown
+		count = len(opt.names) + 1
+	}
+	if len(opt.names) > count-1 {
+		panic(fmt.Sprintf("CallCheckErr func %s %d names for %d results", t.Name, len(opt.names), count))
+	}
+	names := make([]string, count)
+	for i := range names {
+		names[i] = "_"
+		if i < len(opt.names) {
+			names[i] = opt.names[i]
+		}
+	}
+	names[count-1] = "err"
+	define := !t.isNamedResults(names)
+	if opt.define != nil {
+		define = *opt.define
+	}
+	lefts := make([]Value, 0, len(names))
+	for _, name := range names {
+		lefts = append(lefts, NewValue(name, nil))
+	}
+	left := lefts[0]
+	if len(lefts) > 1 {
+		left = NewValues(lefts...)
+	}
+	var assign Value
+	if define {
+		assign = left.AutoSet(v, NewSetOpt().Cast(false))
+	} else {
+		assign = left.Set(v, NewSetOpt().Cast(false))
+	}
+
+	errValue := NewValue("err", NewTypeName("error"))
+	values := make([]Value, 0, len(returns))
+	for _, typ := range returns[:len(returns)-1] {
+		if typ.Kind() == reflect.Ptr {
+			// the Zero of pointer to struct is a new value
+			values = append(values, NewValueNil())
+		} else {
+			values = append(values, typ.Zero())
+		}
+	}
+	if opt.wrap != nil {
+		msg := strings.ReplaceAll(*opt.wrap, "%", "%%") + ": %w"
+		values = append(values, MustToValue("", fmt.Errorf).Call(msg, errValue))
+	} else {
+		values = append(values, errValue)
+	}
+	ret := NewReturn(values[0])
+	if len(values) > 1 {
+		ret = NewReturn(NewValues(values...))
+	}
+	return NewCode().C(assign, NewIf(errValue.NE(NewValueNil()), ret))
+}
+
+// isNamedResults all the names except `_` are the named results of func, like `n, err` of `(n int, err error)`
+func (t *tFunc) isNamedResults(names []string) bool {
+	for _, name := range names {
+		if name == "_" {
+			continue
+		}
+		found := false
+		for _, v := range t.Returns {
+			if v.GetName() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// isErrorType the type is the predeclared error
+func isErrorType(t Type) bool {
+	if t.IsNil() {
+		return t.GetRowStr() == "error" && t.Package() == ""
+	}
+	return t.RefType() == errorType
+}
@@ -0,0 +1,102 @@
+package gocoder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFuncTypeToCode(t *testing.T) {
+	ctxType := NewType(reflect.TypeOf((*context.Context)(nil)).Elem())
+	errType := NewTypeName("error")
+	tests := []struct {
+		name string
+		typ  Type
+		want string
+	}{
+		{
+			name: "no args",
+			typ:  NewTypeFunc(nil, nil),
+			want: "func()",
+		},
+		{
+			name: "unnamed args and returns",
+			typ:  NewTypeFunc([]Arg{NewArg("", ctxType, false), NewArg("", NewTypeI(""), false)}, []Arg{NewArg("", NewTypeI(0), false), NewArg("", errType, false)}),
+			want: "func(context.Context, string) (int, error)",
+		},
+		{
+			name: "variadic",
+			typ:  NewTypeFunc([]Arg{NewArg("format", NewTypeI(""), false), NewArg("args", NewTypeName("interface{}"), true)}, nil),
+			want: "func(format string, args ...interface{})",
+		},
+		{
+			name: "func returns func",
+			typ:  NewTypeFunc(nil, []Arg{NewArg("", NewTypeFunc(nil, []Arg{NewArg("", errType, false)}), false)}),
+			want: "func() func() error",
+		},
+		{
+			name: "slice of func",
+			typ:  NewTypeFunc(nil, nil).Slice(),
+			want: "[]func()",
+		},
+		{
+			name: "reflect func",
+			typ:  NewTypeI(func(context.Context, ...int) error { return nil }),
+			want: "func(context.Context, ...int) error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgTool := NewDefaultPkgTool()
+			typ := tt.typ.Clone()
+			typ.SetInReference(true)
+			if got := ToCode(typ, NewToCodeOpt().PkgTool(pkgTool)); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+			if got := tt.typ.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncTypeImports(t *testing.T) {
+	pkgTool := NewDefaultPkgTool()
+	typ := NewTypeI(func(context.Context) {})
+	typ.SetInReference(true)
+	ToCode(typ, NewToCodeOpt().PkgTool(pkgTool))
+	if _, ok := pkgTool.PkgAliasMap()["context"]; !ok {
+		t.Errorf("PkgAliasMap() = %v, want context imported", pkgTool.PkgAliasMap())
+	}
+}
+
+func TestFuncTypeVariadicNotLast(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic for variadic arg not last")
+		}
+	}()
+	NewTypeFunc([]Arg{NewArg("a", NewTypeI(0), true), NewArg("b", NewTypeI(0), false)}, nil)
+}
+
+func TestAnonymousTypeToCode(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  Type
+		want string
+	}{
+		{name: "empty struct chan", typ: NewTypeChan(NewStruct("", nil), reflect.RecvDir), want: "<-chan struct{}"},
+		{name: "empty interface", typ: NewInterface("", nil).Slice(), want: "[]interface{}"},
+		{name: "struct", typ: NewStruct("", []Field{NewField("A", NewTypeI(0), `json:"a"`)}), want: "struct{ A int `json:\"a\"` }"},
+		{name: "interface", typ: NewInterface("", []Func{NewFunc(FuncTypeDefault, "Len", nil, nil, []Arg{NewArg("", NewTypeI(0), false)})}), want: "interface{ Len() int }"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := tt.typ.Clone()
+			typ.SetInReference(true)
+			if got := ToCode(typ); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package gocoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenericToCode(t *testing.T) {
+	typT := NewTypeName("T")
+	set := NewStruct("Set", []Field{NewField("m", NewTypeName("map[T]struct{}"), "")})
+	set.SetTypeParams([]Arg{NewTypeParam("T", NewTypeName("comparable"))})
+	number := NewTypeUnion(NewTypeApprox(NewTypeI(int(0))), NewTypeApprox(NewTypeI(float64(0))))
+	sum := NewFunc(FuncTypeDefault, "Sum", nil, []Arg{NewArg("vs", typT, true)}, []Arg{NewArg("res", typT, false)})
+	sum.SetTypeParams([]Arg{NewTypeParam("T", number)})
+	mapper := NewTypeDetail("github.com/acme/collection", "Map").Instantiate(NewTypeI(""), NewTypeI(time.Time{}).TackPtr())
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "generic struct",
+			code: set,
+			want: "type Set[T comparable] struct {\nm map[T]struct{}\n}\n",
+		},
+		{
+			name: "generic func with union constraint",
+			code: sum,
+			want: "func Sum[T ~int | ~float64](vs ...T) (res T) {\n}",
+		},
+		{
+			name: "instantiated type",
+			code: NewArg("m", mapper.TackPtr(), false),
+			want: "m *collection.Map[string, *time.Time]",
+		},
+		{
+			name: "instantiated receiver",
+			code: NewReceiver("s", set.Instantiate(typT).TackPtr()),
+			want: "(s *Set[T])",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
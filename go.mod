@@ -5,6 +5,7 @@ go 1.16
 require (
 	github.com/google/go-cmp v0.5.2 // indirect
 	github.com/liasece/log v0.0.2
+	github.com/magiconair/properties v1.8.1
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0 // indirect
 	golang.org/x/mod v0.7.0
@@ -0,0 +1,27 @@
+package gocoder
+
+// Go type, like `go f()`
+type Go interface {
+	Codable
+
+	GetValue() Value
+	InterfaceForGo() bool
+}
+
+var _ Go = (*tGo)(nil)
+
+type tGo struct {
+	Value Value
+}
+
+func (t *tGo) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tGo) GetValue() Value {
+	return t.Value
+}
+
+func (t *tGo) InterfaceForGo() bool {
+	return true
+}
@@ -0,0 +1,34 @@
+package gocoder
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestInterfaceEmbeds(t *testing.T) {
+	closer := NewInterface("Closer", []Func{
+		NewFunc(FuncTypeDefault, "Close", nil, nil, []Arg{NewArg("", NewTypeName("error"), false)}),
+	})
+	readCloser := NewInterfaceEmbeds("ReadCloser", []Type{NewTypeI((*io.Reader)(nil)).Elem(), closer}, []Func{
+		NewFunc(FuncTypeDefault, "Name", nil, nil, []Arg{NewArg("", NewTypeName("string"), false)}),
+	})
+	names := make([]string, 0)
+	for _, f := range readCloser.ExpandFuncs() {
+		names = append(names, f.GetName())
+	}
+	if want := []string{"Name", "Read", "Close"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ExpandFuncs() = %v, want %v", names, want)
+	}
+	if !readCloser.Implements(reflect.TypeOf((*io.ReadCloser)(nil)).Elem()) {
+		t.Errorf("Implements(io.ReadCloser) = false")
+	}
+	want := "type ReadCloser interface {\nio.Reader\nCloser\n Name() string\n}\n"
+	if got := ToCode(readCloser); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+	number := NewInterfaceEmbeds("Number", []Type{NewTypeUnion(NewTypeApprox(NewTypeI(0)), NewTypeApprox(NewTypeI(int64(0))))}, nil)
+	if got, want := ToCode(number, NewToCodeOpt().ExpandEmbeds(true)), "type Number interface {\n~int | ~int64\n}\n"; got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+}
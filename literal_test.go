@@ -0,0 +1,89 @@
+package gocoder
+
+import (
+	"testing"
+	"time"
+)
+
+type testLiteralItem struct {
+	Name  string
+	Count int
+}
+
+func TestLiteralToCode(t *testing.T) {
+	item := NewStruct("Item", []Field{NewField("Name", NewTypeI(""), ""), NewField("At", NewTypeI(time.Time{}), "")})
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "decoded struct",
+			code: item.LiteralKV("Name", "a", "At", NewValueNameI("now", time.Time{})),
+			want: "Item{Name: \"a\", At: now}",
+		},
+		{
+			name: "pointer struct",
+			code: item.TackPtr().LiteralKV("Name", NewValueNameI("name", "")),
+			want: "&Item{Name: name}",
+		},
+		{
+			name: "reflect struct",
+			code: NewTypeI(testLiteralItem{}).LiteralKV("Count", 1),
+			want: "gocoder.testLiteralItem{Count: 1}",
+		},
+		{
+			name: "slice",
+			code: NewTypeI([]int{}).Literal(1, 2, 3),
+			want: "[]int{1, 2, 3}",
+		},
+		{
+			name: "nested slice",
+			code: item.Slice().Literal(item.LiteralKV("Name", "a"), item.Literal(NewKeyValue(NewValue("Name", nil), "b"))),
+			want: "[]Item{Item{Name: \"a\"}, Item{Name: \"b\"}}",
+		},
+		{
+			name: "map",
+			code: NewTypeI(map[string]time.Duration{}).LiteralKV("a", NewValueNameI("time.Second", time.Duration(0))),
+			want: "map[string]time.Duration{\"a\": time.Second}",
+		},
+		{
+			name: "empty",
+			code: item.Literal(),
+			want: "Item{}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiteralUnknownField(t *testing.T) {
+	item := NewStruct("Item", []Field{NewField("Name", NewTypeI(""), "")})
+	for _, f := range []func(){
+		func() { item.LiteralKV("Age", 1) },
+		func() { item.Literal(NewKeyValue(NewValue("Age", nil), 1)) },
+		func() { NewTypeI(testLiteralItem{}).LiteralKV("Age", 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("want panic for unknown field")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestLiteralImports(t *testing.T) {
+	pkgTool := NewDefaultPkgTool()
+	ToCode(NewTypeI([]time.Duration{}).Literal(1), NewToCodeOpt().PkgTool(pkgTool))
+	if _, ok := pkgTool.PkgAliasMap()["time"]; !ok {
+		t.Errorf("PkgAliasMap() = %v, want time imported", pkgTool.PkgAliasMap())
+	}
+}
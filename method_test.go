@@ -0,0 +1,28 @@
+package gocoder
+
+import (
+	"testing"
+)
+
+func TestStructMethods(t *testing.T) {
+	foo := NewStruct("Foo", []Field{NewField("Name", NewTypeI(""), "")})
+	f := NewValue("f", foo.TackPtr())
+	foo.AddMethods([]Func{
+		NewFunc(FuncTypeDefault, "GetName", NewReceiver("f", foo.TackPtr()), nil, []Arg{NewArg("", NewTypeI(""), false)}).C(
+			NewReturn(f.Dot("Name")),
+		),
+	})
+	if foo.FuncByName("GetName") == nil || foo.TackPtr().Elem().FuncByName("GetName") == nil {
+		t.Errorf("FuncByName(GetName) not found")
+	}
+	if _, ok := foo.MethodByName("GetName"); !ok {
+		t.Errorf("MethodByName(GetName) not found")
+	}
+	want := "type Foo struct {\nName string\n}\n\nfunc(f *Foo) GetName() string {\n\treturn f.Name\n}\n"
+	if got := ToCode(foo, NewToCodeOpt().Methods(true)); got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+	if got := foo.Underlying().GetMethods(); len(got) != 0 {
+		t.Errorf("Underlying().GetMethods() len = %d, want 0", len(got))
+	}
+}
@@ -0,0 +1,140 @@
+package gocoder
+
+import (
+	"reflect"
+)
+
+// methodSetEntry a type to search methods at one depth of embedding, ptr means the methods with pointer receiver are in the method set
+type methodSetEntry struct {
+	typ Type
+	ptr bool
+}
+
+// MethodSet the method set of type by the Go spec, the methods of `T` are the methods with value receiver,
+// the methods of `*T` are all the methods, the promoted methods of embedded fields are included,
+// the shallower fields and methods hide the deeper ones, and the ambiguous names at the same depth are excluded
+func (t *tType) MethodSet() []Func {
+	if t.Type != nil {
+		return reflectMethodSet(t.Type)
+	}
+	var entry methodSetEntry
+	if t.Kind() == reflect.Ptr {
+		entry = methodSetEntry{typ: t.Elem().Unalias(), ptr: true}
+		if entry.typ.Kind() == reflect.Interface {
+			// the method set of pointer to interface is empty
+			return nil
+		}
+	} else {
+		entry = methodSetEntry{typ: t.Unalias(), ptr: false}
+	}
+	res := make([]Func, 0)
+	hidden := make(map[string]bool)
+	// the types at the shallower depths, the embedded pointers may be a cycle
+	visited := make(map[string]bool)
+	level := []methodSetEntry{entry}
+	for len(level) > 0 {
+		var next []methodSetEntry
+		var methods []Func
+		count := make(map[string]int)
+		// the same type embedded twice at one depth makes its methods and fields ambiguous
+		times := make(map[string]int)
+		for _, v := range level {
+			key := v.typ.ShowString()
+			if visited[key] {
+				continue
+			}
+			times[key]++
+			names, funcs := declaredMethodSet(v.typ, v.ptr)
+			for _, name := range names {
+				count[name]++
+			}
+			if times[key] == 1 {
+				methods = append(methods, funcs...)
+			}
+			if v.typ.Kind() != reflect.Struct {
+				continue
+			}
+			for i := 0; i < v.typ.NumField(); i++ {
+				f := v.typ.Field(i)
+				count[f.GetName()]++
+				if !f.IsEmbedded() || times[key] > 1 {
+					continue
+				}
+				// like `*Base` in `struct { *Base }`, the methods with pointer receiver of Base are promoted to both S and *S
+				if typ := f.GetType(); typ.IsPtr() {
+					next = append(next, methodSetEntry{typ: typ.Elem().Unalias(), ptr: true})
+				} else {
+					next = append(next, methodSetEntry{typ: typ.Unalias(), ptr: v.ptr})
+				}
+			}
+		}
+		for key := range times {
+			visited[key] = true
+		}
+		for _, f := range methods {
+			if !hidden[f.GetName()] && count[f.GetName()] == 1 {
+				res = append(res, f)
+			}
+		}
+		for name := range count {
+			hidden[name] = true
+		}
+		level = next
+	}
+	return res
+}
+
+// declaredMethodSet the names of all methods declared by type, and the methods in the method set of it
+func declaredMethodSet(t Type, ptr bool) ([]string, []Func) {
+	if !t.IsNil() {
+		rt := t.RefType()
+		if rt.Kind() != reflect.Interface {
+			// the names of the methods with pointer receiver are visible, even they aren't in the method set
+			names := make([]string, 0)
+			for _, f := range reflectMethodSet(reflect.PtrTo(rt)) {
+				names = append(names, f.GetName())
+			}
+			if ptr {
+				rt = reflect.PtrTo(rt)
+			}
+			return names, reflectMethodSet(rt)
+		}
+	}
+	var names []string
+	var funcs []Func
+	if t.Kind() == reflect.Interface {
+		funcs = t.ExpandFuncs()
+		if t.GetRowStr() == "error" && t.Package() == "" {
+			funcs = []Func{NewFunc(FuncTypeDefault, "Error", nil, nil, []Arg{NewArg("", NewTypeI(""), false)})}
+		}
+		for _, f := range funcs {
+			names = append(names, f.GetName())
+		}
+		return names, funcs
+	}
+	for _, f := range t.GetMethods() {
+		names = append(names, f.GetName())
+		if ptr || !isPtrReceiver(f) {
+			funcs = append(funcs, f)
+		}
+	}
+	return names, funcs
+}
+
+func reflectMethodSet(rt reflect.Type) []Func {
+	res := make([]Func, 0, rt.NumMethod())
+	for i := 0; i < rt.NumMethod(); i++ {
+		m := rt.Method(i)
+		args, returns := reflectSignatureArgs(m.Type)
+		if rt.Kind() != reflect.Interface {
+			args = args[1:]
+		}
+		res = append(res, NewFunc(FuncTypeDefault, m.Name, nil, args, returns))
+	}
+	return res
+}
+
+// isPtrReceiver the method is declared with pointer receiver, like `func (f *Foo) Bar()`
+func isPtrReceiver(f Func) bool {
+	return f.GetReceiver() != nil && f.GetReceiver().GetType() != nil && f.GetReceiver().GetType().IsPtr()
+}
@@ -0,0 +1,42 @@
+package gocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMethodSet(t *testing.T) {
+	base := NewStruct("Base", nil)
+	base.AddMethods([]Func{
+		NewFunc(FuncTypeDefault, "ID", NewReceiver("b", base), nil, []Arg{NewArg("", NewTypeI(""), false)}),
+		NewFunc(FuncTypeDefault, "SetID", NewReceiver("b", base.TackPtr()), []Arg{NewArg("id", NewTypeI(""), false)}, nil),
+	})
+	foo := NewStruct("Foo", []Field{
+		NewEmbeddedField(base, ""),
+		NewEmbeddedField(NewTypeI(testStringer(0)), ""),
+	})
+	names := func(fs []Func) []string {
+		res := make([]string, 0)
+		for _, f := range fs {
+			res = append(res, f.GetName())
+		}
+		return res
+	}
+	if got, want := names(foo.MethodSet()), []string{"ID", "String"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MethodSet() = %v, want %v", got, want)
+	}
+	if got, want := names(foo.TackPtr().MethodSet()), []string{"ID", "SetID", "String"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ptr MethodSet() = %v, want %v", got, want)
+	}
+	if got, want := names(NewTypeI(new(testStringer)).MethodSet()), []string{"String"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reflect MethodSet() = %v, want %v", got, want)
+	}
+
+	// Base is embedded twice at the same depth, its methods are ambiguous
+	left := NewStruct("Left", []Field{NewEmbeddedField(base, "")})
+	right := NewStruct("Right", []Field{NewEmbeddedField(base.TackPtr(), "")})
+	both := NewStruct("Both", []Field{NewEmbeddedField(left, ""), NewEmbeddedField(right, "")})
+	if got := names(both.TackPtr().MethodSet()); len(got) != 0 {
+		t.Errorf("ambiguous MethodSet() = %v, want empty", got)
+	}
+}
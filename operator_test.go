@@ -0,0 +1,58 @@
+package gocoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOperatorToCode(t *testing.T) {
+	a := NewValueNameI("a", int(0))
+	b := NewValueNameI("b", int(0))
+	c := NewValueNameI("c", int(0))
+	p := NewValueNameI("p", new(time.Duration))
+	s := NewValueNameI("s", []int{})
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{name: "left associative", code: a.Add(b).Add(c), want: "a + b + c"},
+		{name: "right operand same precedence", code: a.Sub(b.Sub(c)), want: "a - (b - c)"},
+		{name: "higher precedence operand", code: a.Add(b.Mul(c)), want: "a + b * c"},
+		{name: "lower precedence operand", code: a.Add(b).Mul(c), want: "(a + b) * c"},
+		{name: "mod", code: a.Mod(b), want: "a % b"},
+		{name: "bitmask", code: a.BitAnd(b.BitOr(c)), want: "a & (b | c)"},
+		{name: "bit clear", code: a.AndNot(NewValueI(1).Shl(c)), want: "a &^ (1 << c)"},
+		{name: "xor and shr", code: a.BitXor(b.Shr(2)), want: "a ^ b >> 2"},
+		{name: "compare", code: a.Add(1).GT(b.Mul(2)).And(c.NE(0)), want: "a + 1 > b * 2 && c != 0"},
+		{name: "or in and", code: a.GT(0).Or(b.GT(0)).And(c.GT(0)), want: "(a > 0 || b > 0) && c > 0"},
+		{name: "neg", code: a.Add(b).Neg(), want: "-(a + b)"},
+		{name: "double neg", code: a.Neg().Neg(), want: "-(-a)"},
+		{name: "neg of negative literal", code: NewValueI(-1).Neg(), want: "-(-1)"},
+		{name: "neg of negative literal sum", code: NewValueI(-1).Add(a).Neg(), want: "-(-1 + a)"},
+		{name: "double bit not", code: a.BitNot().BitNot(), want: "^(^a)"},
+		{name: "bit not", code: a.BitNot().BitAnd(b), want: "^a & b"},
+		{name: "not", code: a.Equal(b).Not(), want: "!(a == b)"},
+		{name: "un ptr of take ptr", code: a.TakePtr().UnPtr(), want: "*&a"},
+		{name: "un ptr", code: p.UnPtr().Add(1), want: "*p + 1"},
+		{name: "un ptr dot", code: p.UnPtr().Method("String").Call(), want: "(*p).String()"},
+		{name: "inc", code: a.Inc(), want: "a++"},
+		{name: "dec", code: a.Dec(), want: "a--"},
+		{name: "add set", code: a.AddSet(b.Mul(c)), want: "a += b * c"},
+		{name: "shl set", code: a.ShlSet(1), want: "a <<= 1"},
+		{name: "and not set", code: a.AndNotSet(b), want: "a &^= b"},
+		{name: "set", code: a.Set(b.Add(c)), want: "a = b + c"},
+		{name: "slice", code: s.Slice(1, nil), want: "s[1:]"},
+		{name: "slice high", code: s.Slice(nil, a.Add(1)), want: "s[:a + 1]"},
+		{name: "slice3", code: s.Slice3(a, b, c), want: "s[a:b:c]"},
+		{name: "index of expr", code: s.Slice(a, nil).Index(0), want: "s[a:][0]"},
+		{name: "duration literal", code: a.Div(NewValueLiteral(2 * time.Second)), want: "a / (2 * time.Second)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
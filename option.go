@@ -41,6 +41,8 @@ type ToCodeOption struct {
 	pkgName  *string
 	pkgPath  *string
 	noPretty *bool
+	methods  *bool
+	expand   *bool
 }
 
 // NewToCodeOpt func
@@ -76,6 +78,18 @@ func (o *ToCodeOption) NoPretty(v bool) *ToCodeOption {
 	return o
 }
 
+// Methods func, write the methods of type after the type declaration
+func (o *ToCodeOption) Methods(v bool) *ToCodeOption {
+	o.methods = &v
+	return o
+}
+
+// ExpandEmbeds func, write the full method list of interface instead of the embedded interfaces
+func (o *ToCodeOption) ExpandEmbeds(v bool) *ToCodeOption {
+	o.expand = &v
+	return o
+}
+
 // MergeToCodeOpt func
 func MergeToCodeOpt(opts ...*ToCodeOption) *ToCodeOption {
 	var res ToCodeOption
@@ -95,6 +109,67 @@ func MergeToCodeOpt(opts ...*ToCodeOption) *ToCodeOption {
 		if opt.noPretty != nil {
 			res.noPretty = opt.noPretty
 		}
+		if opt.methods != nil {
+			res.methods = opt.methods
+		}
+		if opt.expand != nil {
+			res.expand = opt.expand
+		}
 	}
 	return &res
 }
+
+// CheckErrOption type
+type CheckErrOption struct {
+	names  []string
+	wrap   *string
+	define *bool
+}
+
+// NewCheckErrOpt func
+func NewCheckErrOpt() *CheckErrOption {
+	return &CheckErrOption{
+		names:  nil,
+		wrap:   nil,
+		define: nil,
+	}
+}
+
+// Names func, the names of the results before error, like `n` in `n, err := f()`
+func (o *CheckErrOption) Names(vs ...string) *CheckErrOption {
+	o.names = vs
+	return o
+}
+
+// Wrap func, wrap the returned error, like `fmt.Errorf("load: %w", err)` with msg `load`
+func (o *CheckErrOption) Wrap(msg string) *CheckErrOption {
+	o.wrap = &msg
+	return o
+}
+
+// Define func, declare the results by `:=` or assign them by `=`, like `err = save(n)` if err is declared,
+// the default is `=` for the named results of func and `:=` for others
+func (o *CheckErrOption) Define(v bool) *CheckErrOption {
+	o.define = &v
+	return o
+}
+
+// MergeCheckErrOpt func
+func MergeCheckErrOpt(opts ...*CheckErrOption) *CheckErrOption {
+	res := NewCheckErrOpt()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.names != nil {
+			res.names = opt.names
+		}
+		if opt.wrap != nil {
+			res.wrap = opt.wrap
+		}
+		if opt.define != nil {
+			res.define = opt.define
+		}
+	}
+	return res
+}
@@ -0,0 +1,383 @@
+package gocoder
+
+import (
+	"reflect"
+)
+
+// Case type
+type Case interface {
+	Codable
+
+	GetValues() []Value
+	GetCodes() []Codable
+	IsDefault() bool
+	GetFallthrough() bool
+
+	C(cs ...Codable) Case
+	SetFallthrough(bool)
+
+	InterfaceForCase() bool
+}
+
+// Switch type
+type Switch interface {
+	Codable
+
+	GetInit() Value
+	GetValue() Value
+	GetCases() []Case
+
+	Case(interface{}, ...Codable) Switch
+	Default(...Codable) Switch
+	Fallthrough() Switch
+	C(cs ...Codable) Switch
+	ToCode() Code
+
+	InterfaceForSwitch() bool
+}
+
+// TypeSwitch type
+type TypeSwitch interface {
+	Codable
+
+	GetBind() Value
+	GetValue() Value
+	GetCases() []Case
+
+	Case(interface{}, ...Codable) TypeSwitch
+	Default(...Codable) TypeSwitch
+	C(cs ...Codable) TypeSwitch
+	ToCode() Code
+
+	InterfaceForTypeSwitch() bool
+}
+
+// Select type
+type Select interface {
+	Codable
+
+	GetCases() []Case
+
+	Case(interface{}, ...Codable) Select
+	Default(...Codable) Select
+	C(cs ...Codable) Select
+	ToCode() Code
+
+	InterfaceForSelect() bool
+}
+
+var _ Case = (*tCase)(nil)
+
+type tCase struct {
+	Values      []Value
+	Codes       []Codable
+	Default     bool
+	Fallthrough bool
+}
+
+func (t *tCase) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tCase) GetValues() []Value {
+	return t.Values
+}
+
+func (t *tCase) GetCodes() []Codable {
+	return t.Codes
+}
+
+func (t *tCase) IsDefault() bool {
+	return t.Default
+}
+
+func (t *tCase) GetFallthrough() bool {
+	return t.Fallthrough
+}
+
+func (t *tCase) C(cs ...Codable) Case {
+	t.Codes = append(t.Codes, cs...)
+	return t
+}
+
+func (t *tCase) SetFallthrough(v bool) {
+	t.Fallthrough = v
+}
+
+func (t *tCase) InterfaceForCase() bool {
+	return true
+}
+
+// mustToCaseValues converts a case expression list, like `1` or `[]interface{}{1, 2}`, to values
+func mustToCaseValues(i interface{}) []Value {
+	switch i := i.(type) {
+	case []Value:
+		return i
+	case []interface{}:
+		return MustToValueList(i...)
+	default:
+		return MustToValueList(i)
+	}
+}
+
+// mustToCaseTypeValues converts a type switch case list, like `int` or `[]Type{...}`, to only type values
+func mustToCaseTypeValues(i interface{}) []Value {
+	var is []interface{}
+	switch i := i.(type) {
+	case []Type:
+		for _, v := range i {
+			is = append(is, v)
+		}
+	case []interface{}:
+		is = i
+	default:
+		is = []interface{}{i}
+	}
+	res := make([]Value, 0, len(is))
+	for _, v := range is {
+		switch v := v.(type) {
+		case Value:
+			// like `nil`
+			res = append(res, v)
+		case Type, reflect.Type:
+			typ := MustToType(v).Clone()
+			typ.SetInReference(true)
+			res = append(res, NewOnlyTypeValue(typ))
+		case nil:
+			res = append(res, NewValueNil())
+		default:
+			typ := NewTypeI(v)
+			typ.SetInReference(true)
+			res = append(res, NewOnlyTypeValue(typ))
+		}
+	}
+	return res
+}
+
+func casesToCodes(cases []Case) []Codable {
+	res := make([]Codable, len(cases))
+	for i, v := range cases {
+		res[i] = v
+	}
+	return res
+}
+
+func lastCase(cases []Case) Case {
+	if len(cases) == 0 {
+		panic("no case found")
+	}
+	return cases[len(cases)-1]
+}
+
+var _ Switch = (*tSwitch)(nil)
+
+type tSwitch struct {
+	Init  Value
+	Value Value
+	Cases []Case
+}
+
+func (t *tSwitch) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tSwitch) GetInit() Value {
+	return t.Init
+}
+
+func (t *tSwitch) GetValue() Value {
+	return t.Value
+}
+
+func (t *tSwitch) GetCases() []Case {
+	return t.Cases
+}
+
+func (t *tSwitch) Case(i interface{}, cs ...Codable) Switch {
+	t.Cases = append(t.Cases, &tCase{
+		Values:      mustToCaseValues(i),
+		Codes:       cs,
+		Default:     false,
+		Fallthrough: false,
+	})
+	return t
+}
+
+func (t *tSwitch) Default(cs ...Codable) Switch {
+	t.Cases = append(t.Cases, &tCase{
+		Values:      nil,
+		Codes:       cs,
+		Default:     true,
+		Fallthrough: false,
+	})
+	return t
+}
+
+// Fallthrough func, mark the last case as `fallthrough`
+func (t *tSwitch) Fallthrough() Switch {
+	lastCase(t.Cases).SetFallthrough(true)
+	return t
+}
+
+func (t *tSwitch) C(cs ...Codable) Switch {
+	lastCase(t.Cases).C(cs...)
+	return t
+}
+
+func (t *tSwitch) ToCode() Code {
+	return &tSwitchCode{
+		tSwitch: t,
+	}
+}
+
+func (t *tSwitch) InterfaceForSwitch() bool {
+	return true
+}
+
+type tSwitchCode struct {
+	*tSwitch
+}
+
+func (t *tSwitchCode) C(cs ...Codable) Code {
+	t.tSwitch.C(cs...)
+	return t
+}
+
+func (t *tSwitchCode) GetCodes() []Codable {
+	return casesToCodes(t.Cases)
+}
+
+var _ TypeSwitch = (*tTypeSwitch)(nil)
+
+type tTypeSwitch struct {
+	Bind  Value
+	Value Value
+	Cases []Case
+}
+
+func (t *tTypeSwitch) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tTypeSwitch) GetBind() Value {
+	return t.Bind
+}
+
+func (t *tTypeSwitch) GetValue() Value {
+	return t.Value
+}
+
+func (t *tTypeSwitch) GetCases() []Case {
+	return t.Cases
+}
+
+func (t *tTypeSwitch) Case(i interface{}, cs ...Codable) TypeSwitch {
+	t.Cases = append(t.Cases, &tCase{
+		Values:      mustToCaseTypeValues(i),
+		Codes:       cs,
+		Default:     false,
+		Fallthrough: false,
+	})
+	return t
+}
+
+func (t *tTypeSwitch) Default(cs ...Codable) TypeSwitch {
+	t.Cases = append(t.Cases, &tCase{
+		Values:      nil,
+		Codes:       cs,
+		Default:     true,
+		Fallthrough: false,
+	})
+	return t
+}
+
+func (t *tTypeSwitch) C(cs ...Codable) TypeSwitch {
+	lastCase(t.Cases).C(cs...)
+	return t
+}
+
+func (t *tTypeSwitch) ToCode() Code {
+	return &tTypeSwitchCode{
+		tTypeSwitch: t,
+	}
+}
+
+func (t *tTypeSwitch) InterfaceForTypeSwitch() bool {
+	return true
+}
+
+type tTypeSwitchCode struct {
+	*tTypeSwitch
+}
+
+func (t *tTypeSwitchCode) C(cs ...Codable) Code {
+	t.tTypeSwitch.C(cs...)
+	return t
+}
+
+func (t *tTypeSwitchCode) GetCodes() []Codable {
+	return casesToCodes(t.Cases)
+}
+
+var _ Select = (*tSelect)(nil)
+
+type tSelect struct {
+	Cases []Case
+}
+
+func (t *tSelect) WriteCode(w Writer) {
+	w.WriteCode(t)
+}
+
+func (t *tSelect) GetCases() []Case {
+	return t.Cases
+}
+
+// Case func, i is the communication clause, like `v := <-ch` or `ch <- v`
+func (t *tSelect) Case(i interface{}, cs ...Codable) Select {
+	t.Cases = append(t.Cases, &tCase{
+		Values:      []Value{MustToValue("", i)},
+		Codes:       cs,
+		Default:     false,
+		Fallthrough: false,
+	})
+	return t
+}
+
+func (t *tSelect) Default(cs ...Codable) Select {
+	t.Cases = append(t.Cases, &tCase{
+		Values:      nil,
+		Codes:       cs,
+		Default:     true,
+		Fallthrough: false,
+	})
+	return t
+}
+
+func (t *tSelect) C(cs ...Codable) Select {
+	lastCase(t.Cases).C(cs...)
+	return t
+}
+
+func (t *tSelect) ToCode() Code {
+	return &tSelectCode{
+		tSelect: t,
+	}
+}
+
+func (t *tSelect) InterfaceForSelect() bool {
+	return true
+}
+
+type tSelectCode struct {
+	*tSelect
+}
+
+func (t *tSelectCode) C(cs ...Codable) Code {
+	t.tSelect.C(cs...)
+	return t
+}
+
+func (t *tSelectCode) GetCodes() []Codable {
+	return casesToCodes(t.Cases)
+}
@@ -0,0 +1,60 @@
+package gocoder
+
+import (
+	"testing"
+)
+
+func TestSwitchToCode(t *testing.T) {
+	v := NewValueNameI("v", int(0))
+	x := NewValueNameRef("x", InterfaceType)
+	ch := NewValue("ch", nil)
+	tests := []struct {
+		name string
+		code Codable
+		want string
+	}{
+		{
+			name: "switch",
+			code: NewSwitch(nil, v).
+				Case(1, NewReturn(NewValueI("a"))).Fallthrough().
+				Case([]interface{}{2, 3}, NewReturn(NewValueI("b"))).
+				Default(NewReturn(NewValueI("c"))),
+			want: "switch v {\ncase 1:\n\treturn \"a\"\n\tfallthrough\ncase 2, 3:\n\treturn \"b\"\ndefault:\n\treturn \"c\"\n}",
+		},
+		{
+			name: "switch with init and no tag",
+			code: NewSwitch(NewValue("v", nil).AutoSet(1), nil).
+				Case(v.GT(1), NewReturn(nil)),
+			want: "switch v := 1; {\ncase v > 1:\n\treturn \n}",
+		},
+		{
+			name: "type switch",
+			code: NewTypeSwitch(NewValue("v", nil), x).
+				Case(int(0), NewReturn(NewValueI("int"))).
+				Case([]interface{}{"", NewValueNil()}, NewReturn(NewValueI("string"))).
+				Default(),
+			want: "switch v := x.(type) {\ncase int:\n\treturn \"int\"\ncase string, nil:\n\treturn \"string\"\ndefault:\n}",
+		},
+		{
+			name: "type switch nil",
+			code: NewTypeSwitch(NewValue("v", nil), x).
+				Case(nil, NewReturn(NewValueI("nil"))).
+				Case([]interface{}{int(0), nil}, NewReturn(NewValueI("int"))),
+			want: "switch v := x.(type) {\ncase nil:\n\treturn \"nil\"\ncase int, nil:\n\treturn \"int\"\n}",
+		},
+		{
+			name: "select",
+			code: NewSelect().
+				Case(NewValue("<-ch", nil), NewReturn(nil)).
+				Default().C(ch),
+			want: "select {\ncase <-ch:\n\treturn \ndefault:\n\tch\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.code); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package gocoder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StructTag the parsed struct tag of field, like `json:"name,omitempty" bson:"name"`
+type StructTag interface {
+	Keys() []string
+	Get(key string) string // like `name,omitempty` of `json`
+	Lookup(key string) (string, bool)
+	GetName(key string) string      // like `name` of `json:"name,omitempty"`
+	GetOptions(key string) []string // like `omitempty` of `json:"name,omitempty"`
+	HasOption(key string, option string) bool
+
+	Set(key string, value string) // an existing key keeps its position, a new key is appended
+	SetName(key string, name string)
+	AddOption(key string, options ...string)
+	DelOption(key string, options ...string)
+	Delete(key string)
+	Merge(other StructTag) // the keys of other override the same keys of this tag
+
+	String() string
+	Clone() StructTag
+}
+
+var _ StructTag = (*tStructTag)(nil)
+
+type tStructTag struct {
+	keys   []string
+	values map[string]string
+}
+
+// ParseStructTag parse the raw tag without backticks, like `json:"name,omitempty"`
+func ParseStructTag(raw string) (StructTag, error) {
+	res := &tStructTag{
+		keys:   nil,
+		values: map[string]string{},
+	}
+	// follow the convention of reflect.StructTag.Lookup
+	tag := raw
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, fmt.Errorf("parse struct tag %q: bad syntax at %q", raw, tag)
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, fmt.Errorf("parse struct tag %q: unterminated value of %s", raw, key)
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("parse struct tag %q: value of %s: %w", raw, key, err)
+		}
+		tag = tag[i+1:]
+		if _, ok := res.values[key]; !ok {
+			// like reflect.StructTag.Lookup, the first value of duplicate key is used
+			res.Set(key, value)
+		}
+	}
+	return res, nil
+}
+
+func (t *tStructTag) Keys() []string {
+	return append([]string(nil), t.keys...)
+}
+
+func (t *tStructTag) Get(key string) string {
+	return t.values[key]
+}
+
+func (t *tStructTag) Lookup(key string) (string, bool) {
+	v, ok := t.values[key]
+	return v, ok
+}
+
+func (t *tStructTag) GetName(key string) string {
+	v := t.values[key]
+	if i := strings.Index(v, ","); i >= 0 {
+		return v[:i]
+	}
+	return v
+}
+
+func (t *tStructTag) GetOptions(key string) []string {
+	v := t.values[key]
+	i := strings.Index(v, ",")
+	if i < 0 {
+		return nil
+	}
+	return strings.Split(v[i+1:], ",")
+}
+
+func (t *tStructTag) HasOption(key string, option string) bool {
+	for _, v := range t.GetOptions(key) {
+		if v == option {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tStructTag) Set(key string, value string) {
+	if key == "" || strings.ContainsAny(key, " :\"") {
+		panic(fmt.Sprintf("StructTag.Set invalid key %q", key))
+	}
+	if _, ok := t.values[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.values[key] = value
+}
+
+func (t *tStructTag) SetName(key string, name string) {
+	t.setNameAndOptions(key, name, t.GetOptions(key))
+}
+
+func (t *tStructTag) AddOption(key string, options ...string) {
+	opts := t.GetOptions(key)
+	for _, v := range options {
+		exist := false
+		for _, o := range opts {
+			if o == v {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			opts = append(opts, v)
+		}
+	}
+	t.setNameAndOptions(key, t.GetName(key), opts)
+}
+
+func (t *tStructTag) DelOption(key string, options ...string) {
+	opts := make([]string, 0)
+	for _, v := range t.GetOptions(key) {
+		del := false
+		for _, o := range options {
+			if v == o {
+				del = true
+				break
+			}
+		}
+		if !del {
+			opts = append(opts, v)
+		}
+	}
+	if _, ok := t.values[key]; ok {
+		t.setNameAndOptions(key, t.GetName(key), opts)
+	}
+}
+
+func (t *tStructTag) setNameAndOptions(key string, name string, options []string) {
+	t.Set(key, strings.Join(append([]string{name}, options...), ","))
+}
+
+func (t *tStructTag) Delete(key string) {
+	if _, ok := t.values[key]; !ok {
+		return
+	}
+	delete(t.values, key)
+	for i, v := range t.keys {
+		if v == key {
+			t.keys = append(t.keys[:i:i], t.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (t *tStructTag) Merge(other StructTag) {
+	if other == nil {
+		return
+	}
+	for _, k := range other.Keys() {
+		t.Set(k, other.Get(k))
+	}
+}
+
+func (t *tStructTag) String() string {
+	ss := make([]string, 0, len(t.keys))
+	for _, k := range t.keys {
+		ss = append(ss, k+":"+strconv.Quote(t.values[k]))
+	}
+	return strings.Join(ss, " ")
+}
+
+func (t *tStructTag) Clone() StructTag {
+	res := &tStructTag{
+		keys:   append([]string(nil), t.keys...),
+		values: make(map[string]string, len(t.values)),
+	}
+	for k, v := range t.values {
+		res.values[k] = v
+	}
+	return res
+}
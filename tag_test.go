@@ -0,0 +1,80 @@
+package gocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStructTag(t *testing.T) {
+	tag := NewStructTag(`json:"name,omitempty" bson:"name" validate:"required,max=10"`)
+	if got, want := tag.Keys(), []string{"json", "bson", "validate"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if tag.GetName("json") != "name" || !tag.HasOption("json", "omitempty") || tag.HasOption("bson", "omitempty") {
+		t.Errorf("json tag = %q", tag.Get("json"))
+	}
+	if got, want := tag.GetOptions("validate"), []string{"max=10"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOptions(validate) = %v, want %v", got, want)
+	}
+
+	tag.SetName("bson", "_id")
+	tag.AddOption("bson", "omitempty", "omitempty")
+	tag.DelOption("json", "omitempty")
+	tag.Delete("validate")
+	tag.Set("yaml", `a "b"`)
+	want := `json:"name" bson:"_id,omitempty" yaml:"a \"b\""`
+	if got := tag.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := reflect.StructTag(tag.String()).Get("yaml"); got != `a "b"` {
+		t.Errorf("reflect Get(yaml) = %q", got)
+	}
+
+	merged := NewStructTag(`db:"id" json:"-"`)
+	merged.Merge(tag)
+	if got, want := merged.String(), `db:"id" json:"name" bson:"_id,omitempty" yaml:"a \"b\""`; got != want {
+		t.Errorf("Merge() = %q, want %q", got, want)
+	}
+}
+
+func TestStructTagInvalid(t *testing.T) {
+	for _, raw := range []string{`json`, `json:name`, `json:"name`, `:"x"`} {
+		if _, err := ParseStructTag(raw); err == nil {
+			t.Errorf("ParseStructTag(%q) want error", raw)
+		}
+	}
+}
+
+func TestStructTagDuplicateKey(t *testing.T) {
+	raw := `json:"first" json:"second"`
+	tag, err := ParseStructTag(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tag.Get("json"), reflect.StructTag(raw).Get("json"); got != want {
+		t.Errorf("Get(json) = %q, want %q", got, want)
+	}
+}
+
+func TestFieldStructTagInvalid(t *testing.T) {
+	f := NewField("ID", NewTypeI(""), `json:id`)
+	if _, err := f.GetStructTag(); err == nil {
+		t.Errorf("GetStructTag() of %q want error", f.GetTag())
+	}
+}
+
+func TestFieldStructTag(t *testing.T) {
+	f := NewField("ID", NewTypeI(""), `json:"id"`)
+	tag, err := f.GetStructTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag.Set("bson", "_id")
+	if f.GetTag() != `json:"id"` {
+		t.Errorf("GetStructTag() should be a copy, GetTag() = %q", f.GetTag())
+	}
+	f.SetStructTag(tag)
+	if got, want := ToCode(f), "ID string `json:\"id\" bson:\"_id\"`\n"; got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+}
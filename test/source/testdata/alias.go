@@ -0,0 +1,23 @@
+package alias
+
+import "time"
+
+// Status of the order
+type Status string
+
+// Deadline is an alias of time.Time
+type Deadline = time.Time
+
+type Order struct {
+	ID string
+}
+
+type OrderAlias = Order
+
+type Orders []Order
+
+type Item struct {
+	Status   Status
+	Deadline Deadline
+	Orders   Orders
+}
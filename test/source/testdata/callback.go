@@ -0,0 +1,20 @@
+package callback
+
+import "context"
+
+type Event struct {
+	Name string
+}
+
+// Handler handle the event
+type Handler func(ctx context.Context, e *Event) error
+
+type Bus struct {
+	OnEvent  func(ctx context.Context, e *Event) (int, error)
+	OnClose  func()
+	Handlers []Handler
+}
+
+func (b *Bus) Subscribe(name string, f func(*Event) error) func() {
+	return nil
+}
@@ -0,0 +1,11 @@
+package chans
+
+type Job struct {
+	ID int
+}
+
+type Pool struct {
+	Jobs    chan *Job
+	Results <-chan int
+	Done    chan<- bool
+}
@@ -0,0 +1,31 @@
+package embed
+
+import "time"
+
+type Base struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+type Audit struct {
+	By string
+}
+
+type Meta struct {
+	ID      int
+	Version int
+}
+
+type Doc struct {
+	Base
+	// Audit of the doc
+	*Audit  `json:"audit"`
+	Title   string
+	Version int
+}
+
+type Page struct {
+	Doc
+	Meta
+	Title string
+}
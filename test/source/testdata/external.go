@@ -0,0 +1,14 @@
+package external
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Order struct {
+	ID     primitive.ObjectID
+	Trace  uuid.UUID
+	Amount decimal.Decimal
+	Items  []*uuid.UUID
+}
@@ -0,0 +1,47 @@
+package generic
+
+type Item struct {
+	Name string `json:"name"`
+}
+
+type Number interface {
+	int | int64 | float64
+}
+
+// List is a generic list
+type List[T any] struct {
+	Items []T
+	Len   int
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type Getter[T any] interface {
+	Get() T
+	Set(v T) error
+}
+
+type Holder struct {
+	Items List[Item]
+	Pairs []Pair[string, *Item]
+}
+
+func (l *List[T]) Add(v T) {
+	l.Items = append(l.Items, v)
+	l.Len++
+}
+
+func (l List[T]) Get(i int) T {
+	return l.Items[i]
+}
+
+func Sum[T ~int | ~float64](vs ...T) T {
+	var res T
+	for _, v := range vs {
+		res += v
+	}
+	return res
+}
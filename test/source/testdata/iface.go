@@ -0,0 +1,28 @@
+package iface
+
+import (
+	"context"
+	"io"
+)
+
+type Getter interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+type Setter interface {
+	Set(ctx context.Context, key string, value string) error
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// Store the composed interface
+type Store interface {
+	Getter
+	Setter
+	io.Closer
+	Len() int
+}
+
+type Number interface {
+	~int | ~int64
+	String() string
+}
@@ -0,0 +1,42 @@
+package implement
+
+type Repo interface {
+	Get(id string) (string, error)
+	Put(id string, v string) error
+}
+
+type MemRepo struct{}
+
+func (r MemRepo) Get(id string) (string, error) {
+	return "", nil
+}
+
+func (r MemRepo) Put(id string, v string) error {
+	return nil
+}
+
+type DBRepo struct{}
+
+func (r *DBRepo) Get(id string) (string, error) {
+	return "", nil
+}
+
+func (r *DBRepo) Put(id string, v string) error {
+	return nil
+}
+
+type BadRepo struct{}
+
+func (r BadRepo) Get(id string) string {
+	return ""
+}
+
+type CachedRepo struct {
+	MemRepo
+}
+
+type Unrelated struct{}
+
+type ReadOnly interface {
+	Get(id string) (string, error)
+}
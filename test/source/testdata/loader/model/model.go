@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type User struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+func (u *User) Name() string {
+	return u.ID
+}
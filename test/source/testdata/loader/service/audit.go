@@ -0,0 +1,10 @@
+package service
+
+import (
+	. "github.com/liasece/gocoder/test/source/testdata/loader/model"
+)
+
+type Audit struct {
+	By    *User
+	Notes []string
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	m "github.com/liasece/gocoder/test/source/testdata/loader/model"
+)
+
+type UserService interface {
+	Get(ctx context.Context, id string) (*m.User, error)
+	Export(w io.Writer, users ...m.User) error
+}
+
+type Report struct {
+	Owner m.User
+	Body  io.Reader
+}
@@ -0,0 +1,8 @@
+package stream
+
+import "io"
+
+type Reader struct {
+	X   int
+	Src io.Reader
+}
@@ -0,0 +1,24 @@
+package method
+
+type Counter struct {
+	N int
+}
+
+// Inc add one
+func (c *Counter) Inc() {
+	c.N++
+}
+
+func (c Counter) Value() int {
+	return c.N
+}
+
+type Level int
+
+func (l Level) String() string {
+	return ""
+}
+
+type Namer interface {
+	Name() string
+}
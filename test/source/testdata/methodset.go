@@ -0,0 +1,45 @@
+package methodset
+
+type Logger struct{}
+
+func (l Logger) Log(msg string) {}
+
+func (l *Logger) SetLevel(level int) {}
+
+type Closer interface {
+	Close() error
+}
+
+type Store struct{}
+
+func (s *Store) Get(key string) string {
+	return ""
+}
+
+func (s Store) Name() string {
+	return ""
+}
+
+type Service struct {
+	Logger
+	*Store
+	Closer
+}
+
+func (s *Service) Start() error {
+	return nil
+}
+
+type Other struct{}
+
+func (o Other) Log(msg string) {}
+
+type Conflict struct {
+	Logger
+	Other
+}
+
+type Shadow struct {
+	Logger
+	Log string
+}
@@ -0,0 +1,13 @@
+package registry
+
+type Entry struct {
+	Name string
+}
+
+type Registry struct {
+	Entries map[string]*Entry
+	Index   map[Entry]int
+	Groups  map[string][]Entry
+	Hash    [16]byte
+	Slots   [4]*Entry
+}
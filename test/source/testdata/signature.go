@@ -0,0 +1,18 @@
+package signature
+
+import "context"
+
+type Config struct {
+	Name string
+}
+
+type Option func(c *Config)
+
+type Service interface {
+	Copy(dst, src []byte) (n int, err error)
+	Log(format string, args ...interface{})
+	Apply(opts ...Option) error
+	Watch(ctx context.Context, onEvent func(name string, ok bool) error, done <-chan struct{}) (<-chan string, error)
+	Pair() (string, string)
+	Swap(a, b, _ int) (x, y int)
+}
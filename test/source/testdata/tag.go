@@ -0,0 +1,7 @@
+package tag
+
+type User struct {
+	ID    string `json:"id,omitempty" bson:"_id"`
+	Name  string "json:\"name\""
+	Email string
+}
@@ -1,6 +1,7 @@
 package gocoder
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 )
@@ -14,12 +15,16 @@ type Type interface {
 	IsPtr() bool
 	IsSlice() bool
 	IsMap() bool
+	IsChan() bool
+	GetChanDir() reflect.ChanDir
 	UnPtr() Type
 	IsStruct() bool
 	TackPtr() Type
 	Slice() Type
 	IsNil() bool
 	Elem() Type
+	Key() Type // like `string` in `map[string]int`
+	Len() int  // like `4` in `[4]byte`
 	Kind() reflect.Kind
 	SetKind(reflect.Kind)
 	String() string
@@ -34,11 +39,17 @@ type Type interface {
 	FieldTypeByName(name string) (Type, bool)
 	MethodByName(name string) (reflect.Method, bool)
 	Zero() Value
+	Literal(elems ...interface{}) Value
+	LiteralKV(kvs ...interface{}) Value
 	Name() string
 	GetNamed() string
 	GetRowStr() string
 	SetNamed(string)
 	WarpNamed(named string) Type // build type like `type Foo SubType`, named is `Foo`
+	WarpAlias(named string) Type // build type like `type Foo = SubType`, named is `Foo`
+	IsAlias() bool
+	Unalias() Type    // like `SubType` of `type Foo = SubType`, the type itself if it isn't an alias
+	Underlying() Type // like `string` of `type Foo string`
 	SetPkg(string)
 	GetNext() Type
 
@@ -53,10 +64,29 @@ type Type interface {
 	GetFields() []Field
 	AddFields([]Field)
 	FieldByName(name string) Field
+	FlattenFields() []Field // the fields with the promoted fields instead of the embedded fields
 
 	// interface
 	GetFuncs() []Func
-	FuncByName(name string) Func
+	GetEmbeds() []Type // like `io.Reader` or `~int | ~string` in `interface { io.Reader; ~int | ~string }`
+	AddEmbeds([]Type)
+	ExpandFuncs() []Func // the funcs of interface with the funcs of the embedded interfaces
+	GetMethods() []Func  // the methods declared with this type as receiver, like `func (f *Foo) Bar()`
+	AddMethods([]Func)
+	FuncByName(name string) Func // search in the funcs of interface and the methods
+	MethodSet() []Func           // the method set of `T` or `*T`, include the promoted methods
+
+	// generic
+	GetTypeParams() []Arg // like `T any` in `type Set[T any] struct{}`
+	SetTypeParams([]Arg)
+	GetTypeArgs() []Type // like `int` in `Set[int]`
+	Instantiate(args ...interface{}) Type
+	GetUnionTerms() []Type // like `~int` and `~string` in `~int | ~string`
+
+	// func signature
+	IsFunc() bool
+	GetFuncArgs() []Arg
+	GetFuncReturns() []Arg
 
 	Clone() Type
 }
@@ -71,12 +101,29 @@ type tType struct {
 	Pkg   string // like `time` or `github.com/liasece/gocoder`
 	Named string // like `Foo` in `type Foo string`
 	Next  Type
+	alias bool // like `type Foo = string`, the Named is an alias of Next
+
+	// map, like `string` in `map[string]int`, the elem type is Next
+	mapKey Type
 
 	// struct
 	fields []Field
 
 	// interface
-	funcs []Func
+	funcs  []Func
+	embeds []Type // the embedded interfaces and type set elements
+
+	// the methods declared with this type as receiver
+	methods []Func
+
+	// generic
+	typeParams []Arg
+	typeArgs   []Type
+	terms      []Type
+
+	// func signature, like `func(ctx context.Context) error`
+	funcArgs    []Arg
+	funcReturns []Arg
 
 	inReference bool // like: `boo int`, the int is inReference
 	kind        reflect.Kind
@@ -95,10 +142,28 @@ func (t *tType) Clone() Type {
 		kind:        t.kind,
 		fields:      t.fields,
 		funcs:       t.funcs,
+		methods:     t.methods,
+		typeParams:  t.typeParams,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    t.funcArgs,
+		funcReturns: t.funcReturns,
+		mapKey:      nil,
+		alias:       t.alias,
+		embeds:      t.embeds,
 	}
 	if t.Next != nil {
 		res.Next = t.Next.Clone()
 	}
+	if t.mapKey != nil {
+		res.mapKey = t.mapKey.Clone()
+	}
+	if t.typeArgs != nil {
+		res.typeArgs = cloneTypes(t.typeArgs)
+	}
+	if t.terms != nil {
+		res.terms = cloneTypes(t.terms)
+	}
 	if t.fields != nil {
 		res.fields = make([]Field, len(t.fields))
 		for i, v := range t.fields {
@@ -108,6 +173,14 @@ func (t *tType) Clone() Type {
 	return res
 }
 
+func cloneTypes(ts []Type) []Type {
+	res := make([]Type, len(ts))
+	for i, v := range ts {
+		res[i] = v.Clone()
+	}
+	return res
+}
+
 func (t *tType) WriteCode(w Writer) {
 	w.WriteCode(t)
 }
@@ -135,6 +208,48 @@ func (t *tType) IsMap() bool {
 	return t.Kind() == reflect.Map
 }
 
+func (t *tType) IsChan() bool {
+	return t.Kind() == reflect.Chan
+}
+
+func (t *tType) GetChanDir() reflect.ChanDir {
+	if t.Type != nil && t.Type.Kind() == reflect.Chan {
+		return t.Type.ChanDir()
+	}
+	switch t.Str {
+	case chanDirPrefix(reflect.RecvDir):
+		return reflect.RecvDir
+	case chanDirPrefix(reflect.SendDir):
+		return reflect.SendDir
+	}
+	return reflect.BothDir
+}
+
+// chanDirPrefix like `chan `, `<-chan ` or `chan<- `
+func chanDirPrefix(dir reflect.ChanDir) string {
+	switch dir {
+	case reflect.RecvDir:
+		return "<-chan "
+	case reflect.SendDir:
+		return "chan<- "
+	}
+	return "chan "
+}
+
+// chanElemNeedParent the receive-only chan element of bidirectional chan, like `chan (<-chan int)`,
+// because `chan <-chan int` is `chan<- (chan int)` in Go
+func chanElemNeedParent(prefix string, elem string) bool {
+	return prefix == chanDirPrefix(reflect.BothDir) && strings.HasPrefix(elem, "<-chan")
+}
+
+// chanElemString join the prefix and element string of type, the element is parenthesized if need
+func chanElemString(prefix string, elem string) string {
+	if chanElemNeedParent(prefix, elem) {
+		return prefix + "(" + elem + ")"
+	}
+	return prefix + elem
+}
+
 // list all type chian nodes, top type in last index
 func (t *tType) AllSub() []Type {
 	if t.Next != nil {
@@ -222,6 +337,15 @@ func (t *tType) UnPtr() Type {
 			kind:        0,
 			fields:      nil,
 			funcs:       nil,
+			typeParams:  nil,
+			typeArgs:    nil,
+			terms:       nil,
+			funcArgs:    nil,
+			funcReturns: nil,
+			mapKey:      nil,
+			alias:       false,
+			methods:     nil,
+			embeds:      nil,
 		}
 	}
 	return t
@@ -241,6 +365,15 @@ func (t *tType) TackPtr() Type {
 				kind:        0,
 				fields:      nil,
 				funcs:       nil,
+				typeParams:  nil,
+				typeArgs:    nil,
+				terms:       nil,
+				funcArgs:    nil,
+				funcReturns: nil,
+				mapKey:      nil,
+				alias:       false,
+				methods:     nil,
+				embeds:      nil,
 			}
 		}
 		return t
@@ -257,6 +390,15 @@ func (t *tType) TackPtr() Type {
 			kind:        0,
 			fields:      nil,
 			funcs:       nil,
+			typeParams:  nil,
+			typeArgs:    nil,
+			terms:       nil,
+			funcArgs:    nil,
+			funcReturns: nil,
+			mapKey:      nil,
+			alias:       false,
+			methods:     nil,
+			embeds:      nil,
 		}
 	}
 	return t
@@ -275,6 +417,15 @@ func (t *tType) Slice() Type {
 			kind:        0,
 			fields:      nil,
 			funcs:       nil,
+			typeParams:  nil,
+			typeArgs:    nil,
+			terms:       nil,
+			funcArgs:    nil,
+			funcReturns: nil,
+			mapKey:      nil,
+			alias:       false,
+			methods:     nil,
+			embeds:      nil,
 		}
 	}
 	if t.Kind() != reflect.Ptr {
@@ -293,20 +444,70 @@ func (t *tType) Slice() Type {
 			kind:        0,
 			fields:      nil,
 			funcs:       nil,
+			typeParams:  nil,
+			typeArgs:    nil,
+			terms:       nil,
+			funcArgs:    nil,
+			funcReturns: nil,
+			mapKey:      nil,
+			alias:       false,
+			methods:     nil,
+			embeds:      nil,
 		}
 	}
 	return t
 }
 
 func (t *tType) Elem() Type {
-	if t.Str == "[]" || t.Str == "*" {
+	if t.Str == "[]" || t.Str == "*" || (t.Type == nil && t.Next != nil && (t.kind == reflect.Chan || t.kind == reflect.Map || t.kind == reflect.Array)) {
 		return t.Next
 	}
+	if t.isNamedWrapper() {
+		return t.Next.Elem()
+	}
+	if t.Type == nil {
+		panic(fmt.Sprintf("Elem of invalid type %s", t.String()))
+	}
 	res := t.Clone().(*tType)
 	res.Type = t.Type.Elem()
 	return res
 }
 
+func (t *tType) Key() Type {
+	if t.mapKey != nil {
+		return t.mapKey
+	}
+	if t.isNamedWrapper() {
+		return t.Next.Key()
+	}
+	if t.Type == nil || t.Type.Kind() != reflect.Map {
+		panic(fmt.Sprintf("Key of non-map type %s", t.String()))
+	}
+	return NewType(t.Type.Key())
+}
+
+func (t *tType) Len() int {
+	if t.Type == nil && t.kind == reflect.Array {
+		var res int
+		if _, err := fmt.Sscanf(t.Str, "[%d]", &res); err != nil {
+			panic(fmt.Sprintf("Len of invalid array type %s", t.String()))
+		}
+		return res
+	}
+	if t.isNamedWrapper() {
+		return t.Next.Len()
+	}
+	if t.Type == nil || t.Type.Kind() != reflect.Array {
+		panic(fmt.Sprintf("Len of non-array type %s", t.String()))
+	}
+	return t.Type.Len()
+}
+
+// isNamedWrapper the type is built by WarpNamed, like `Foo` in `type Foo []int`
+func (t *tType) isNamedWrapper() bool {
+	return t.Type == nil && t.Str == "" && t.Named != "" && t.Next != nil
+}
+
 func (t *tType) Package() string {
 	if t.Pkg != "" {
 		return t.Pkg
@@ -332,9 +533,9 @@ func (t *tType) CurrentCode() string {
 		}
 		switch t.Type.Kind() {
 		case reflect.Array:
-			return "[]"
+			return fmt.Sprintf("[%d]", t.Type.Len())
 		case reflect.Chan:
-			return "chan"
+			return chanDirPrefix(t.Type.ChanDir())
 		case reflect.Map:
 			return "map[" + t.Type.Key().Name() + "]"
 		case reflect.Pointer:
@@ -353,11 +554,17 @@ func (t *tType) CurrentCode() string {
 }
 
 func (t *tType) String() string {
+	if len(t.terms) > 0 {
+		return joinTypeStrings(t.terms, " | ", Type.String)
+	}
+	if t.isSignature() {
+		return signatureString(t.funcArgs, t.funcReturns, Type.String)
+	}
 	res := ""
 	if t.Str != "" {
 		res = t.Str
 		if t.Next != nil {
-			res += t.Next.String()
+			res = chanElemString(res, t.Next.String())
 		}
 	}
 	if t.Type != nil {
@@ -374,21 +581,45 @@ func (t *tType) String() string {
 			res = t.Next.String()
 		}
 	}
+	if len(t.typeArgs) > 0 {
+		res += "[" + joinTypeStrings(t.typeArgs, ", ", Type.String) + "]"
+	}
 	return res
 }
 
+func joinTypeStrings(ts []Type, sep string, f func(Type) string) string {
+	strs := make([]string, len(ts))
+	for i, v := range ts {
+		strs[i] = f(v)
+	}
+	return strings.Join(strs, sep)
+}
+
 func (t *tType) ShowString() string {
+	if len(t.terms) > 0 {
+		return joinTypeStrings(t.terms, " | ", Type.ShowString)
+	}
+	if t.isSignature() {
+		return signatureString(t.funcArgs, t.funcReturns, Type.ShowString)
+	}
 	head := ""
 	if t.Package() != "" {
 		head = t.Package() + "."
 	}
 	if t.Named != "" {
-		return head + t.Named
+		res := head + t.Named
+		if len(t.typeArgs) > 0 {
+			res += "[" + joinTypeStrings(t.typeArgs, ", ", Type.ShowString) + "]"
+		}
+		return res
+	}
+	if t.mapKey != nil {
+		return "map[" + t.mapKey.ShowString() + "]" + t.Next.ShowString()
 	}
 	if t.Str != "" {
 		res := head + t.Str
 		if t.Next != nil {
-			res += t.Next.ShowString()
+			res = chanElemString(res, t.Next.ShowString())
 		}
 		return res
 	}
@@ -399,6 +630,23 @@ func (t *tType) ShowString() string {
 		}
 		return str
 	}
+	if t.kind == reflect.Struct {
+		strs := make([]string, len(t.fields))
+		for i, v := range t.fields {
+			strs[i] = v.GetName() + " " + v.GetType().ShowString()
+		}
+		return "struct{" + strings.Join(strs, "; ") + "}"
+	}
+	if t.kind == reflect.Interface && t.Next == nil {
+		strs := make([]string, 0, len(t.embeds)+len(t.funcs))
+		for _, v := range t.embeds {
+			strs = append(strs, v.ShowString())
+		}
+		for _, v := range t.funcs {
+			strs = append(strs, v.GetName()+strings.TrimPrefix(signatureKey(v.GetArgs(), v.GetReturns()), "func"))
+		}
+		return "interface{" + strings.Join(strs, "; ") + "}"
+	}
 
 	res := head + t.Named
 	if t.Next != nil {
@@ -409,12 +657,18 @@ func (t *tType) ShowString() string {
 
 func (t *tType) ConvertibleTo(i interface{}) bool {
 	u := MustToType(i)
-	return t.Type.ConvertibleTo(u.RefType())
+	if t.Type != nil && !u.IsNil() {
+		return t.Type.ConvertibleTo(u.RefType())
+	}
+	return symbolicConvertible(t, u)
 }
 
 func (t *tType) Implements(i interface{}) bool {
 	u := MustToType(i)
-	return t.Type.Implements(u.RefType())
+	if t.Type != nil && !u.IsNil() {
+		return t.Type.Implements(u.RefType())
+	}
+	return symbolicImplements(t, u)
 }
 
 func (t *tType) FieldByName(name string) Field {
@@ -424,48 +678,106 @@ func (t *tType) FieldByName(name string) Field {
 				return f
 			}
 		}
-	}
-	{
-		f, ok := t.Type.FieldByName(name)
-		if !ok {
-			return nil
-		}
-		return &tField{
-			TNoteCode: TNoteCode{nil},
-			Type:      NewType(f.Type),
-			ReName:    f.Name,
-			Tag:       string(f.Tag),
+		for _, f := range t.FlattenFields() {
+			if f.GetName() == name {
+				return f
+			}
 		}
 	}
-}
-
-func (t *tType) FieldTypeByName(name string) (Type, bool) {
-	if t.kind == reflect.Struct && t.fields != nil {
-		f := t.FieldByName(name)
-		if f != nil {
-			return f.GetType(), true
+	if t.isNamedWrapper() {
+		return t.Next.FieldByName(name)
+	}
+	if t.Type == nil {
+		if t.Kind() == reflect.Ptr && t.Next != nil {
+			// like `f.Name` of `f *Foo`
+			return t.Next.FieldByName(name)
 		}
+		return nil
 	}
 	f, ok := t.Type.FieldByName(name)
 	if !ok {
+		return nil
+	}
+	return newReflectField(f)
+}
+
+func (t *tType) FieldTypeByName(name string) (Type, bool) {
+	f := t.FieldByName(name)
+	if f == nil {
 		return nil, false
 	}
-	return NewType(f.Type), true
+	return f.GetType(), true
 }
 
 func (t *tType) NumField() int {
-	if t.kind == reflect.Struct && t.fields != nil {
-		return len(t.GetFields())
+	if t.Type != nil {
+		return t.Type.NumField()
+	}
+	if t.isNamedWrapper() {
+		return t.Next.NumField()
 	}
-	return t.Type.NumField()
+	return len(t.fields)
 }
 
 func (t *tType) Field(i int) Field {
-	if t.kind == reflect.Struct && t.fields != nil {
-		return t.GetFields()[i]
+	if t.Type != nil {
+		return newReflectField(t.Type.Field(i))
+	}
+	if t.isNamedWrapper() {
+		return t.Next.Field(i)
+	}
+	return t.fields[i]
+}
+
+// FlattenFields list the fields of struct, the embedded fields are replaced by their promoted fields,
+// the shallower field hides the deeper ones with the same name, and the ambiguous fields are dropped
+func (t *tType) FlattenFields() []Field {
+	res := make([]Field, 0)
+	hidden := make(map[string]bool)
+	// the types at the shallower depths, the embedded pointers may be a cycle
+	visited := make(map[string]bool)
+	level := []Type{t}
+	for len(level) > 0 {
+		var next []Type
+		var fields []Field
+		count := make(map[string]int)
+		// the same type embedded twice at one depth makes its fields ambiguous
+		times := make(map[string]int)
+		for _, typ := range level {
+			if typ.IsPtr() {
+				typ = typ.Elem()
+			}
+			key := typ.ShowString()
+			if typ.Kind() != reflect.Struct || visited[key] {
+				continue
+			}
+			times[key]++
+			for i := 0; i < typ.NumField(); i++ {
+				f := typ.Field(i)
+				count[f.GetName()]++
+				if times[key] > 1 {
+					continue
+				}
+				fields = append(fields, f)
+				if f.IsEmbedded() {
+					next = append(next, f.GetType())
+				}
+			}
+		}
+		for key := range times {
+			visited[key] = true
+		}
+		for _, f := range fields {
+			if !f.IsEmbedded() && !hidden[f.GetName()] && count[f.GetName()] == 1 {
+				res = append(res, f)
+			}
+		}
+		for name := range count {
+			hidden[name] = true
+		}
+		level = next
 	}
-	f := t.Type.Field(i)
-	return NewField(f.Name, NewTypeI(f.Type), string(f.Tag))
+	return res
 }
 
 func (t *tType) Kind() reflect.Kind {
@@ -484,7 +796,7 @@ func (t *tType) Kind() reflect.Kind {
 	if t.kind == reflect.Struct && t.fields != nil {
 		return reflect.Struct
 	}
-	if t.Type == nil && t.Str == "" && t.Named != "" && t.Next != nil {
+	if t.isNamedWrapper() {
 		return t.Next.Kind()
 	}
 	return t.RefType().Kind()
@@ -494,8 +806,23 @@ func (t *tType) SetKind(v reflect.Kind) {
 	t.kind = v
 }
 
+// MethodByName the Type and Func of the symbolic method are nil, use FuncByName to get the signature
 func (t *tType) MethodByName(name string) (reflect.Method, bool) {
-	return t.Type.MethodByName(name)
+	if t.Type != nil {
+		return t.Type.MethodByName(name)
+	}
+	for i, f := range t.allFuncs() {
+		if f.GetName() == name {
+			return reflect.Method{
+				Name:    name,
+				PkgPath: "",
+				Type:    nil,
+				Func:    reflect.Value{},
+				Index:   i,
+			}, true
+		}
+	}
+	return reflect.Method{}, false
 }
 
 func (t *tType) Name() string {
@@ -539,6 +866,15 @@ func (t *tType) GetNext() Type {
 			kind:        0,
 			fields:      nil,
 			funcs:       nil,
+			typeParams:  nil,
+			typeArgs:    nil,
+			terms:       nil,
+			funcArgs:    nil,
+			funcReturns: nil,
+			mapKey:      nil,
+			alias:       false,
+			methods:     nil,
+			embeds:      nil,
 		}
 	}
 	return nil
@@ -570,6 +906,64 @@ func (t *tType) Zero() Value {
 	}
 }
 
+// Literal build a composite literal, like `[]T{a, b}` or `T{A: a}` with NewKeyValue elements
+func (t *tType) Literal(elems ...interface{}) Value {
+	vs := make([]Value, 0, len(elems))
+	for _, v := range elems {
+		value := MustToValue("", v)
+		if value.GetAction() == ValueActionKeyValue {
+			if key, ok := value.GetLeft().(Value); ok && key.GetAction() == ValueActionNone && key.GetName() != "" {
+				checkLiteralField(t, key.GetName())
+			}
+		}
+		vs = append(vs, value)
+	}
+	return NewLiteral(t, vs...)
+}
+
+// LiteralKV build a keyed composite literal from key value pairs, like `T{A: x, B: y}` or `map[K]V{k: v}`,
+// the key of struct literal is field name and must be found in struct fields
+func (t *tType) LiteralKV(kvs ...interface{}) Value {
+	if len(kvs)%2 != 0 {
+		panic("LiteralKV args must be key value pairs")
+	}
+	isStruct := t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct)
+	vs := make([]Value, 0, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		var key Value
+		if name, ok := kvs[i].(string); ok && isStruct {
+			checkLiteralField(t, name)
+			key = NewValue(name, nil)
+		} else {
+			key = MustToValue("", kvs[i])
+		}
+		vs = append(vs, NewKeyValue(key, kvs[i+1]))
+	}
+	return NewLiteral(t, vs...)
+}
+
+func checkLiteralField(t Type, name string) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	if fs := t.GetFields(); fs != nil {
+		for _, f := range fs {
+			if f.GetName() == name {
+				return
+			}
+		}
+		panic(fmt.Sprintf("literal of %s no target field: %s", t.String(), name))
+	}
+	if rt := t.RefType(); rt != nil && rt.Kind() == reflect.Struct {
+		if _, ok := rt.FieldByName(name); !ok {
+			panic(fmt.Sprintf("literal of %s no target field: %s", t.String(), name))
+		}
+	}
+}
+
 func (t *tType) InterfaceForType() bool {
 	return true
 }
@@ -594,8 +988,65 @@ func (t *tType) GetFuncs() []Func {
 	return t.funcs
 }
 
-func (t *tType) FuncByName(name string) Func {
+func (t *tType) GetEmbeds() []Type {
+	return t.embeds
+}
+
+func (t *tType) AddEmbeds(ts []Type) {
+	t.embeds = append(t.embeds, ts...)
+}
+
+// ExpandFuncs the funcs of interface, include the funcs of the embedded interfaces recursively,
+// like `Read` and `Close` of `interface { io.Reader; Close() error }`
+func (t *tType) ExpandFuncs() []Func {
+	if t.isNamedWrapper() {
+		return t.Next.ExpandFuncs()
+	}
+	if t.Type != nil && t.Type.Kind() == reflect.Interface {
+		return reflectMethodSet(t.Type)
+	}
+	if len(t.embeds) == 0 {
+		return t.funcs
+	}
+	res := append([]Func(nil), t.funcs...)
+	names := make(map[string]bool, len(t.funcs))
 	for _, f := range t.funcs {
+		names[f.GetName()] = true
+	}
+	for _, e := range t.embeds {
+		if !isEmbeddedInterface(e) {
+			continue
+		}
+		// the same methods of embedded interfaces are allowed since go1.14
+		for _, f := range e.MethodSet() {
+			if !names[f.GetName()] {
+				names[f.GetName()] = true
+				res = append(res, f)
+			}
+		}
+	}
+	return res
+}
+
+func (t *tType) GetMethods() []Func {
+	return t.methods
+}
+
+func (t *tType) AddMethods(fs []Func) {
+	t.methods = append(t.methods, fs...)
+}
+
+// allFuncs the funcs of type, include the funcs of the underlying interface, like `Foo` in `type Foo io.Reader`, and the methods
+func (t *tType) allFuncs() []Func {
+	funcs := t.ExpandFuncs()
+	if len(t.methods) == 0 {
+		return funcs
+	}
+	return append(append(make([]Func, 0, len(funcs)+len(t.methods)), funcs...), t.methods...)
+}
+
+func (t *tType) FuncByName(name string) Func {
+	for _, f := range t.allFuncs() {
 		if f.GetName() == name {
 			return f
 		}
@@ -615,5 +1066,100 @@ func (t *tType) WarpNamed(named string) Type {
 		kind:        t.kind,
 		fields:      nil,
 		funcs:       nil,
+		typeParams:  nil,
+		typeArgs:    nil,
+		terms:       nil,
+		funcArgs:    nil,
+		funcReturns: nil,
+		mapKey:      nil,
+		alias:       false,
+		methods:     nil,
+		embeds:      nil,
+	}
+}
+
+func (t *tType) WarpAlias(named string) Type {
+	res := t.WarpNamed(named).(*tType)
+	res.alias = true
+	return res
+}
+
+func (t *tType) IsAlias() bool {
+	return t.alias
+}
+
+func (t *tType) Unalias() Type {
+	if t.alias {
+		return t.Next.Unalias()
+	}
+	return t
+}
+
+// Underlying the struct and interface type of reflect keep the named type, because the unnamed one can't be built
+func (t *tType) Underlying() Type {
+	if t.isNamedWrapper() {
+		return t.Next.Underlying()
+	}
+	if t.Type != nil {
+		if rt := reflectUnderlying(t.Type); rt != t.Type {
+			return NewType(rt)
+		}
+		return t
 	}
+	if t.Named != "" && (t.kind == reflect.Struct || t.kind == reflect.Interface) {
+		// like `struct { A int }` of `type Foo struct { A int }`
+		res := t.Clone().(*tType)
+		res.SetNotes(nil)
+		res.Named = ""
+		res.Pkg = ""
+		res.typeParams = nil
+		res.methods = nil
+		return res
+	}
+	return t
+}
+
+func (t *tType) GetTypeParams() []Arg {
+	return t.typeParams
+}
+
+func (t *tType) SetTypeParams(v []Arg) {
+	t.typeParams = v
+}
+
+func (t *tType) GetTypeArgs() []Type {
+	return t.typeArgs
+}
+
+// Instantiate build a generic type instantiation, like `Set[int]` from `Set`
+func (t *tType) Instantiate(args ...interface{}) Type {
+	res := t.Clone().(*tType)
+	res.SetNotes(nil)
+	res.typeParams = nil
+	res.typeArgs = make([]Type, 0, len(args))
+	for _, v := range args {
+		res.typeArgs = append(res.typeArgs, MustToType(v))
+	}
+	return res
+}
+
+func (t *tType) GetUnionTerms() []Type {
+	return t.terms
+}
+
+func (t *tType) IsFunc() bool {
+	return t.Kind() == reflect.Func
+}
+
+// isSignature the type is a symbolic func signature, like `func(int) error`
+func (t *tType) isSignature() bool {
+	return t.kind == reflect.Func && t.Type == nil && t.Str == "" && t.Named == ""
+}
+
+func (t *tType) GetFuncArgs() []Arg {
+	return t.funcArgs
+}
+
+func (t *tType) GetFuncReturns() []Arg {
+	return t.funcReturns
 }
@@ -0,0 +1,205 @@
+package gocoder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"unsafe"
+)
+
+// typeIdentical compare type by the full name of package, like `time.Time`
+func typeIdentical(a Type, b Type) bool {
+	return a.ShowString() == b.ShowString()
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uintptr
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Complex128
+}
+
+func isComplexKind(k reflect.Kind) bool {
+	return k == reflect.Complex64 || k == reflect.Complex128
+}
+
+// isBytesOrRunes like `[]byte` or `[]rune`, can be converted from and to string
+func isBytesOrRunes(t Type) bool {
+	t = t.Underlying()
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	k := t.Elem().Kind()
+	return k == reflect.Uint8 || k == reflect.Int32
+}
+
+// symbolicConvertible the conversion rules of go spec, for the types without reflect type
+func symbolicConvertible(t Type, u Type) bool {
+	if typeIdentical(t, u) || typeIdentical(t.Underlying(), u.Underlying()) {
+		return true
+	}
+	tk, uk := t.Kind(), u.Kind()
+	switch {
+	case isNumericKind(tk) && isNumericKind(uk):
+		return isComplexKind(tk) == isComplexKind(uk)
+	case uk == reflect.String:
+		return isIntegerKind(tk) || isBytesOrRunes(t)
+	case tk == reflect.String:
+		return isBytesOrRunes(u)
+	case tk == reflect.Ptr && uk == reflect.Ptr:
+		return typeIdentical(t.Elem().Underlying(), u.Elem().Underlying())
+	case uk == reflect.Interface:
+		return symbolicImplements(t, u)
+	}
+	return false
+}
+
+// symbolicImplements check the methods of interface by name and signature, for the types without reflect type
+func symbolicImplements(t Type, u Type) bool {
+	if u.Kind() != reflect.Interface {
+		return false
+	}
+	missing, mismatched := MissingMethods(t, u)
+	return len(missing) == 0 && len(mismatched) == 0
+}
+
+// MissingMethods check the methods of interface by name and signature, get the sorted names of the methods
+// not in the method set of type, and the names of the methods with a different signature
+func MissingMethods(t Type, iface Type) ([]string, []string) {
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("MissingMethods %s is not an interface", iface.String()))
+	}
+	wants := interfaceMethodSignatures(iface)
+	names := make([]string, 0, len(wants))
+	for name := range wants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var missing, mismatched []string
+	for _, name := range names {
+		got, ok := methodSignature(t, name)
+		if !ok {
+			missing = append(missing, name)
+		} else if want := wants[name]; want != "" && got != "" && got != want {
+			mismatched = append(mismatched, name)
+		}
+	}
+	return missing, mismatched
+}
+
+// interfaceMethodSignatures the signature of interface methods by name, like `Error: func() string`
+func interfaceMethodSignatures(u Type) map[string]string {
+	res := make(map[string]string)
+	if !u.IsNil() {
+		rt := u.RefType()
+		for i := 0; i < rt.NumMethod(); i++ {
+			m := rt.Method(i)
+			args, returns := reflectSignatureArgs(m.Type)
+			res[m.Name] = signatureKey(args, returns)
+		}
+		return res
+	}
+	if u.GetRowStr() == "error" && u.Package() == "" {
+		res["Error"] = signatureKey(nil, []Arg{NewArg("", NewTypeI(""), false)})
+		return res
+	}
+	for _, f := range u.ExpandFuncs() {
+		res[f.GetName()] = signatureKey(f.GetArgs(), f.GetReturns())
+	}
+	return res
+}
+
+// methodSignature get the signature of method without receiver
+func methodSignature(t Type, name string) (string, bool) {
+	if !t.IsNil() {
+		rt := t.RefType()
+		m, ok := rt.MethodByName(name)
+		if !ok {
+			return "", false
+		}
+		args, returns := reflectSignatureArgs(m.Type)
+		if rt.Kind() != reflect.Interface {
+			args = args[1:]
+		}
+		return signatureKey(args, returns), true
+	}
+	for _, f := range t.MethodSet() {
+		if f.GetName() == name {
+			return signatureKey(f.GetArgs(), f.GetReturns()), true
+		}
+	}
+	return "", false
+}
+
+// signatureKey the func signature without arg names, like `func(context.Context) error`
+func signatureKey(args []Arg, returns []Arg) string {
+	unnamed := func(vs []Arg) []Arg {
+		res := make([]Arg, len(vs))
+		for i, v := range vs {
+			res[i] = NewArg("", v.GetType(), v.GetVariableLength())
+		}
+		return res
+	}
+	return signatureString(unnamed(args), unnamed(returns), func(t Type) string {
+		if t == nil {
+			return ""
+		}
+		return t.ShowString()
+	})
+}
+
+// the unnamed types of basic kinds, like `string` of `time.Month`
+var reflectBasicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:          reflect.TypeOf(false),
+	reflect.Int:           reflect.TypeOf(int(0)),
+	reflect.Int8:          reflect.TypeOf(int8(0)),
+	reflect.Int16:         reflect.TypeOf(int16(0)),
+	reflect.Int32:         reflect.TypeOf(int32(0)),
+	reflect.Int64:         reflect.TypeOf(int64(0)),
+	reflect.Uint:          reflect.TypeOf(uint(0)),
+	reflect.Uint8:         reflect.TypeOf(uint8(0)),
+	reflect.Uint16:        reflect.TypeOf(uint16(0)),
+	reflect.Uint32:        reflect.TypeOf(uint32(0)),
+	reflect.Uint64:        reflect.TypeOf(uint64(0)),
+	reflect.Uintptr:       reflect.TypeOf(uintptr(0)),
+	reflect.Float32:       reflect.TypeOf(float32(0)),
+	reflect.Float64:       reflect.TypeOf(float64(0)),
+	reflect.Complex64:     reflect.TypeOf(complex64(0)),
+	reflect.Complex128:    reflect.TypeOf(complex128(0)),
+	reflect.String:        reflect.TypeOf(""),
+	reflect.UnsafePointer: reflect.TypeOf(unsafe.Pointer(nil)),
+}
+
+// reflectUnderlying like `int64` of `time.Duration`, the struct and interface types are returned as is
+func reflectUnderlying(rt reflect.Type) reflect.Type {
+	if rt.Name() == "" || rt.PkgPath() == "" {
+		return rt
+	}
+	if res, ok := reflectBasicTypes[rt.Kind()]; ok {
+		return res
+	}
+	switch rt.Kind() {
+	case reflect.Array:
+		return reflect.ArrayOf(rt.Len(), rt.Elem())
+	case reflect.Chan:
+		return reflect.ChanOf(rt.ChanDir(), rt.Elem())
+	case reflect.Map:
+		return reflect.MapOf(rt.Key(), rt.Elem())
+	case reflect.Ptr:
+		return reflect.PtrTo(rt.Elem())
+	case reflect.Slice:
+		return reflect.SliceOf(rt.Elem())
+	case reflect.Func:
+		ins := make([]reflect.Type, rt.NumIn())
+		for i := range ins {
+			ins[i] = rt.In(i)
+		}
+		outs := make([]reflect.Type, rt.NumOut())
+		for i := range outs {
+			outs[i] = rt.Out(i)
+		}
+		return reflect.FuncOf(ins, outs, rt.IsVariadic())
+	}
+	return rt
+}
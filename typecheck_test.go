@@ -0,0 +1,172 @@
+package gocoder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSymbolicTypeElem(t *testing.T) {
+	names := NewTypeDetail("github.com/acme/x", "[]string").WarpNamed("Names")
+	if names.Kind() != reflect.Slice {
+		t.Errorf("named Kind() = %v, want slice", names.Kind())
+	}
+	if got := names.Elem().String(); got != "string" {
+		t.Errorf("named Elem() = %q, want string", got)
+	}
+	index := NewTypeMap(NewTypeDetail("github.com/acme/x", "ID"), NewTypeI(0))
+	if !index.IsMap() || index.Key().ShowString() != "github.com/acme/x.ID" || index.Elem().Kind() != reflect.Int {
+		t.Errorf("map Key() = %s, Elem() = %s", index.Key().ShowString(), index.Elem().String())
+	}
+	if got := NewTypeI([3]int{}).Len(); got != 3 {
+		t.Errorf("reflect Len() = %d, want 3", got)
+	}
+	if got := NewTypeArray(8, NewTypeName("Foo")).Len(); got != 8 {
+		t.Errorf("symbolic Len() = %d, want 8", got)
+	}
+}
+
+func TestSymbolicTypeConvertibleTo(t *testing.T) {
+	status := NewTypeI("").WarpNamed("Status")
+	status.SetPkg("github.com/acme/x")
+	tests := []struct {
+		name string
+		from Type
+		to   interface{}
+		want bool
+	}{
+		{name: "named to underlying", from: status, to: "", want: true},
+		{name: "underlying to named", from: NewTypeName("string"), to: status, want: true},
+		{name: "numeric", from: NewTypeName("int32"), to: NewTypeName("float64"), want: true},
+		{name: "numeric to complex", from: NewTypeName("int32"), to: NewTypeName("complex64"), want: false},
+		{name: "bytes to string", from: NewTypeName("[]byte"), to: status, want: true},
+		{name: "string to runes", from: status, to: NewTypeName("[]rune"), want: true},
+		{name: "string to ints", from: status, to: NewTypeName("[]int"), want: false},
+		{name: "map", from: NewTypeName("map[string]Foo"), to: NewTypeName("map[string]Foo"), want: true},
+		{name: "map elem", from: NewTypeName("map[string]Foo"), to: NewTypeName("map[string]Bar"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.ConvertibleTo(tt.to); got != tt.want {
+				t.Errorf("ConvertibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testStringer int
+
+func (testStringer) String() string {
+	return ""
+}
+
+func TestSymbolicTypeImplements(t *testing.T) {
+	stringer := NewInterface("Stringer", []Func{
+		NewFunc(FuncTypeDefault, "String", nil, nil, []Arg{NewArg("", NewTypeName("string"), false)}),
+	})
+	reader := NewInterface("Reader", []Func{
+		NewFunc(FuncTypeDefault, "Read", nil, []Arg{NewArg("p", NewTypeName("[]byte"), false)}, []Arg{NewArg("n", NewTypeName("int"), false), NewArg("err", NewTypeName("error"), false)}),
+	})
+	readCloser := NewInterface("ReadCloser", append(reader.GetFuncs(),
+		NewFunc(FuncTypeDefault, "Close", nil, nil, []Arg{NewArg("", NewTypeName("error"), false)}),
+	))
+	tests := []struct {
+		name  string
+		from  Type
+		iface interface{}
+		want  bool
+	}{
+		{name: "reflect type to symbolic interface", from: NewTypeI(testStringer(0)), iface: stringer, want: true},
+		{name: "reflect type missing method", from: NewTypeI(0), iface: stringer, want: false},
+		{name: "symbolic interface to reflect interface", from: stringer, iface: reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), want: true},
+		{name: "sub interface", from: readCloser, iface: reader, want: true},
+		{name: "super interface", from: reader, iface: readCloser, want: false},
+		{name: "signature mismatch", from: reader, iface: NewInterface("Reader", []Func{NewFunc(FuncTypeDefault, "Read", nil, nil, nil)}), want: false},
+		{name: "named interface", from: readCloser, iface: reader.WarpNamed("MyReader"), want: true},
+		{name: "error", from: NewInterface("Err", []Func{NewFunc(FuncTypeDefault, "Error", nil, nil, []Arg{NewArg("", NewTypeName("string"), false)})}), iface: NewTypeName("error"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.Implements(tt.iface); got != tt.want {
+				t.Errorf("Implements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSymbolicTypeMethod(t *testing.T) {
+	reader := NewInterface("Reader", []Func{
+		NewFunc(FuncTypeDefault, "Read", nil, []Arg{NewArg("p", NewTypeName("[]byte"), false)}, []Arg{NewArg("n", NewTypeName("int"), false), NewArg("err", NewTypeName("error"), false)}),
+	})
+	if m, ok := reader.MethodByName("Read"); !ok || m.Name != "Read" {
+		t.Errorf("MethodByName(Read) = %v, %v", m, ok)
+	}
+	if _, ok := reader.MethodByName("Close"); ok {
+		t.Errorf("MethodByName(Close) found")
+	}
+	r := NewValue("r", reader)
+	call := r.Method("Read").Call(NewValue("buf", NewTypeName("[]byte")))
+	rs := call.Type().GetFuncReturns()
+	if len(rs) != 2 || rs[0].GetType().String() != "int" || rs[1].GetType().String() != "error" {
+		t.Fatalf("GetFuncReturns() = %v, want int and error", rs)
+	}
+	if got := ToCode(call); got != "r.Read(buf)" {
+		t.Errorf("ToCode() = %q, want %q", got, "r.Read(buf)")
+	}
+}
+
+func TestTypeUnderlying(t *testing.T) {
+	status := NewTypeI("").WarpNamed("Status")
+	level := status.WarpAlias("Level")
+	tests := []struct {
+		name string
+		typ  Type
+		want string
+		code string
+	}{
+		{name: "reflect basic", typ: NewTypeI(time.Second), want: "int64"},
+		{name: "reflect slice", typ: NewTypeI(sort.IntSlice{}), want: "[]int"},
+		{name: "reflect struct", typ: NewTypeI(time.Time{}), want: "time.Time"},
+		{name: "defined", typ: status, want: "string", code: "type Status string\n"},
+		{name: "alias of defined", typ: level, want: "string", code: "type Level = Status\n"},
+		{name: "struct", typ: NewStruct("Foo", []Field{NewField("A", NewTypeI(0), "")}), want: "struct{A int}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.typ.Underlying().ShowString(); got != tt.want {
+				t.Errorf("Underlying() = %q, want %q", got, tt.want)
+			}
+			if tt.code != "" {
+				if got := ToCode(tt.typ); got != tt.code {
+					t.Errorf("ToCode() = %q, want %q", got, tt.code)
+				}
+			}
+		})
+	}
+	if !level.IsAlias() || level.Unalias().GetNamed() != "Status" || status.Unalias() != status {
+		t.Errorf("Unalias() = %s, want Status", level.Unalias().String())
+	}
+}
+
+func TestMissingMethods(t *testing.T) {
+	reader := NewInterface("ReadCloser", []Func{
+		NewFunc(FuncTypeDefault, "Read", nil, []Arg{NewArg("p", NewTypeName("[]byte"), false)}, []Arg{NewArg("n", NewTypeName("int"), false), NewArg("err", NewTypeName("error"), false)}),
+		NewFunc(FuncTypeDefault, "Close", nil, nil, []Arg{NewArg("", NewTypeName("error"), false)}),
+		NewFunc(FuncTypeDefault, "String", nil, nil, []Arg{NewArg("", NewTypeName("string"), false)}),
+	})
+	foo := NewStruct("Foo", nil)
+	foo.AddMethods([]Func{
+		NewFunc(FuncTypeDefault, "Read", NewReceiver("f", foo.TackPtr()), []Arg{NewArg("p", NewTypeName("[]byte"), false)}, []Arg{NewArg("n", NewTypeName("int"), false), NewArg("err", NewTypeName("error"), false)}),
+		NewFunc(FuncTypeDefault, "String", NewReceiver("f", foo), nil, []Arg{NewArg("", NewTypeName("int"), false)}),
+	})
+	missing, mismatched := MissingMethods(foo, reader)
+	if !reflect.DeepEqual(missing, []string{"Close", "Read"}) || !reflect.DeepEqual(mismatched, []string{"String"}) {
+		t.Errorf("MissingMethods() = %v, %v", missing, mismatched)
+	}
+	missing, _ = MissingMethods(foo.TackPtr(), reader)
+	if !reflect.DeepEqual(missing, []string{"Close"}) {
+		t.Errorf("ptr MissingMethods() missing = %v, want [Close]", missing)
+	}
+}
@@ -0,0 +1,494 @@
+package gocoder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// the kinds of predeclared types, these types never belong to a package
+var predeclaredTypeKinds = map[string]reflect.Kind{
+	"bool":       reflect.Bool,
+	"int":        reflect.Int,
+	"int8":       reflect.Int8,
+	"int16":      reflect.Int16,
+	"int32":      reflect.Int32,
+	"int64":      reflect.Int64,
+	"uint":       reflect.Uint,
+	"uint8":      reflect.Uint8,
+	"uint16":     reflect.Uint16,
+	"uint32":     reflect.Uint32,
+	"uint64":     reflect.Uint64,
+	"uintptr":    reflect.Uintptr,
+	"byte":       reflect.Uint8,
+	"rune":       reflect.Int32,
+	"float32":    reflect.Float32,
+	"float64":    reflect.Float64,
+	"complex64":  reflect.Complex64,
+	"complex128": reflect.Complex128,
+	"string":     reflect.String,
+	"error":      reflect.Interface,
+	"any":        reflect.Interface,
+	"comparable": reflect.Interface,
+}
+
+// typeExprParser parse a go type expression with full import path, like `map[string]*github.com/acme/x.Foo`
+type typeExprParser struct {
+	pkg string // the package of unqualified named type
+	src string
+	pos int
+}
+
+// paramEntry a single entry of func params, like `ctx context.Context` or `a` in `a, b int`
+type paramEntry struct {
+	name     string
+	raw      string
+	typ      Type
+	variadic bool
+}
+
+// typeParseError the parser panics with it, and parseTypeExpr recovers it as the error
+type typeParseError struct {
+	msg string
+}
+
+func (e *typeParseError) Error() string {
+	return e.msg
+}
+
+func parseTypeExpr(pkg string, src string) (res Type, err error) {
+	p := &typeExprParser{
+		pkg: pkg,
+		src: src,
+		pos: 0,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*typeParseError)
+			if !ok {
+				panic(r)
+			}
+			res, err = nil, e
+		}
+	}()
+	res = p.parseType()
+	p.skipSpace()
+	if p.pos != len(p.src) {
+		p.fail("unexpected %q", p.src[p.pos:])
+	}
+	return res, nil
+}
+
+func (p *typeExprParser) fail(format string, args ...interface{}) {
+	panic(&typeParseError{
+		msg: fmt.Sprintf("parse type %q at %d: %s", p.src, p.pos, fmt.Sprintf(format, args...)),
+	})
+}
+
+func (p *typeExprParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *typeExprParser) peek(s string) bool {
+	p.skipSpace()
+	return strings.HasPrefix(p.src[p.pos:], s)
+}
+
+func (p *typeExprParser) consume(s string) bool {
+	if p.peek(s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *typeExprParser) expect(s string) {
+	if !p.consume(s) {
+		p.fail("expect %q", s)
+	}
+}
+
+// consumeKeyword like consume, but the keyword can't be a prefix of a name, like `map` in `mapper.Foo`
+func (p *typeExprParser) consumeKeyword(keyword string) bool {
+	if !p.peek(keyword) {
+		return false
+	}
+	if end := p.pos + len(keyword); end < len(p.src) && isTypeNameChar(p.src[end]) {
+		return false
+	}
+	p.pos += len(keyword)
+	return true
+}
+
+// canStartType the next char can be the beginning of a type, like the result of `func() error`
+func (p *typeExprParser) canStartType() bool {
+	p.skipSpace()
+	return p.pos < len(p.src) && !strings.ContainsRune(",)]}|=;\r\n", rune(p.src[p.pos]))
+}
+
+func (p *typeExprParser) parseType() Type {
+	switch {
+	case p.consume("("):
+		res := p.parseType()
+		p.expect(")")
+		return res
+	case p.consume("*"):
+		return newTypeNode("", "*", reflect.Ptr, p.parseType())
+	case p.consume("[]"):
+		return newTypeNode("", "[]", reflect.Slice, p.parseType())
+	case p.consume("["):
+		length := p.parseArrayLen()
+		p.expect("]")
+		return NewTypeArray(length, p.parseType())
+	case p.consumeKeyword("map"):
+		p.expect("[")
+		key := p.parseType()
+		p.expect("]")
+		return NewTypeMap(key, p.parseType())
+	case p.consume("<-"):
+		if !p.consumeKeyword("chan") {
+			p.fail("expect chan")
+		}
+		return NewTypeChan(p.parseType(), reflect.RecvDir)
+	case p.consumeKeyword("chan"):
+		dir := reflect.BothDir
+		if p.consume("<-") {
+			dir = reflect.SendDir
+		}
+		return NewTypeChan(p.parseType(), dir)
+	case p.consumeKeyword("func"):
+		return p.parseFunc()
+	case p.consumeKeyword("interface"):
+		return p.parseInterfaceBody()
+	case p.consumeKeyword("struct"):
+		return p.parseStructBody()
+	}
+	return p.parseNamed()
+}
+
+func (p *typeExprParser) parseArrayLen() int {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	length, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		p.fail("invalid array length")
+	}
+	return length
+}
+
+// skipBodySep skip the spaces, new lines and semicolons between the entries of struct or interface body
+func (p *typeExprParser) skipBodySep() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n;", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// peekIdent the identifier at the current position, the position isn't changed
+func (p *typeExprParser) peekIdent() string {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.src) && isParamNameChar(p.src[end]) {
+		end++
+	}
+	return p.src[p.pos:end]
+}
+
+// parseStructBody like `{ A, B int; Base; *pkg.Meta `json:"meta"` }` after `struct`
+func (p *typeExprParser) parseStructBody() Type {
+	p.expect("{")
+	p.skipBodySep()
+	if p.consume("}") {
+		return newTypeNode("", "struct{}", reflect.Struct, nil)
+	}
+	fields := make([]Field, 0)
+	for {
+		fields = append(fields, p.parseStructFields()...)
+		p.skipBodySep()
+		if p.consume("}") {
+			break
+		}
+		if p.pos >= len(p.src) {
+			p.fail("expect %q", "}")
+		}
+	}
+	return NewStruct("", fields)
+}
+
+// parseStructFields like `A, B int`, `Base` or `*pkg.Meta` with the optional tag
+func (p *typeExprParser) parseStructFields() []Field {
+	names := make([]string, 0)
+	if name := p.peekIdent(); name != "" {
+		pos := p.pos
+		p.pos += len(name)
+		next := ""
+		if p.pos < len(p.src) {
+			next = p.src[p.pos : p.pos+1]
+		}
+		switch {
+		case next == "." || next == "/" || next == "[" || next == "-":
+			// the embedded qualified or generic type, like `pkg.Base` or `List[int]`
+			p.pos = pos
+		case p.peek(",") || p.canStartType() && !p.peek("\"") && !p.peek("`"):
+			names = append(names, name)
+			for p.consume(",") {
+				name = p.peekIdent()
+				if name == "" {
+					p.fail("expect field name")
+				}
+				p.pos += len(name)
+				names = append(names, name)
+			}
+		default:
+			// the embedded type, like `Base`
+			p.pos = pos
+		}
+	}
+	typ := p.parseType()
+	tag := p.parseTag()
+	if len(names) == 0 {
+		return []Field{NewEmbeddedField(typ, tag)}
+	}
+	res := make([]Field, 0, len(names))
+	for i, name := range names {
+		if i > 0 {
+			typ = typ.Clone()
+		}
+		res = append(res, NewField(name, typ, tag))
+	}
+	return res
+}
+
+// parseTag the optional tag of struct field, like `json:"name"` or "json:\"name\""
+func (p *typeExprParser) parseTag() string {
+	p.skipSpace()
+	if p.pos >= len(p.src) || (p.src[p.pos] != '`' && p.src[p.pos] != '"') {
+		return ""
+	}
+	quote := p.src[p.pos]
+	end := p.pos + 1
+	for end < len(p.src) && p.src[end] != quote {
+		if quote == '"' && p.src[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(p.src) {
+		p.fail("unterminated tag")
+	}
+	tag, err := strconv.Unquote(p.src[p.pos : end+1])
+	if err != nil {
+		p.fail("invalid tag: %s", err.Error())
+	}
+	p.pos = end + 1
+	return tag
+}
+
+// parseInterfaceBody like `{ io.Reader; Close() error; ~int | ~string }` after `interface`
+func (p *typeExprParser) parseInterfaceBody() Type {
+	p.expect("{")
+	p.skipBodySep()
+	if p.consume("}") {
+		return newTypeNode("", "interface{}", reflect.Interface, nil)
+	}
+	embeds := make([]Type, 0)
+	funcs := make([]Func, 0)
+	for {
+		if name := p.peekIdent(); name != "" {
+			pos := p.pos
+			p.pos += len(name)
+			if p.peek("(") {
+				args := p.parseParams()
+				var returns []Arg
+				if p.peek("(") {
+					returns = p.parseParams()
+				} else if p.canStartType() {
+					returns = []Arg{NewArg("", p.parseType(), false)}
+				}
+				funcs = append(funcs, NewFunc(FuncTypeDefault, name, nil, args, returns))
+			} else {
+				p.pos = pos
+				embeds = append(embeds, p.parseTypeElem())
+			}
+		} else {
+			embeds = append(embeds, p.parseTypeElem())
+		}
+		p.skipBodySep()
+		if p.consume("}") {
+			break
+		}
+		if p.pos >= len(p.src) {
+			p.fail("expect %q", "}")
+		}
+	}
+	if len(embeds) == 0 {
+		embeds = nil
+	}
+	return NewInterfaceEmbeds("", embeds, funcs)
+}
+
+// parseTypeElem the embedded type or type set element of interface, like `io.Reader` or `~int | ~string`
+func (p *typeExprParser) parseTypeElem() Type {
+	terms := make([]Type, 0)
+	for {
+		if p.consume("~") {
+			terms = append(terms, NewTypeApprox(p.parseType()))
+		} else {
+			terms = append(terms, p.parseType())
+		}
+		if !p.consume("|") {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return NewTypeUnion(terms...)
+}
+
+// parseNamed like `int`, `Foo`, `time.Time`, `github.com/acme/x.Foo` or `Set[int]`
+func (p *typeExprParser) parseNamed() Type {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && isTypeNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	full := p.src[start:p.pos]
+	if full == "" {
+		p.fail("expect type")
+	}
+	pkg, name := "", full
+	if i := strings.LastIndex(full, "."); i > strings.LastIndex(full, "/") {
+		pkg, name = full[:i], full[i+1:]
+	}
+	if name == "" || strings.Contains(name, "/") {
+		p.fail("invalid type name %q", full)
+	}
+	kind, predeclared := predeclaredTypeKinds[name]
+	if pkg == "" && !predeclared {
+		pkg = p.pkg
+	}
+	if pkg != "" {
+		kind = 0
+	}
+	res := newTypeNode(pkg, name, kind, nil)
+	// the type args must follow the name closely, `x [4]int` in params is a named array
+	if p.pos < len(p.src) && p.src[p.pos] == '[' {
+		p.pos++
+		args := make([]interface{}, 0)
+		for {
+			args = append(args, p.parseType())
+			if !p.consume(",") {
+				break
+			}
+		}
+		p.expect("]")
+		res = res.Instantiate(args...)
+	}
+	return res
+}
+
+// parseFunc like `(ctx context.Context, vs ...int) (int, error)` after `func`
+func (p *typeExprParser) parseFunc() Type {
+	args := p.parseParams()
+	var returns []Arg
+	if p.peek("(") {
+		returns = p.parseParams()
+	} else if p.canStartType() {
+		returns = []Arg{NewArg("", p.parseType(), false)}
+	}
+	return NewTypeFunc(args, returns)
+}
+
+func (p *typeExprParser) parseParams() []Arg {
+	p.expect("(")
+	entries := make([]paramEntry, 0)
+	named := false
+	for !p.consume(")") {
+		start := p.pos
+		entry := paramEntry{
+			name:     p.parseParamName(),
+			raw:      "",
+			typ:      nil,
+			variadic: false,
+		}
+		named = named || entry.name != ""
+		entry.variadic = p.consume("...")
+		entry.typ = p.parseType()
+		entry.raw = strings.TrimSpace(p.src[start:p.pos])
+		entries = append(entries, entry)
+		if !p.consume(",") {
+			p.expect(")")
+			break
+		}
+	}
+	res := make([]Arg, 0, len(entries))
+	for i, v := range entries {
+		if named && v.name == "" {
+			// the grouped names share the type of the next named param, like `a, b int`
+			if !isParamName(v.raw) {
+				p.fail("mixed named and unnamed params")
+			}
+			v.name = v.raw
+			v.typ = nil
+			for _, next := range entries[i+1:] {
+				if next.name != "" {
+					v.typ = next.typ.Clone()
+					break
+				}
+			}
+			if v.typ == nil {
+				p.fail("missing type of param %s", v.name)
+			}
+		}
+		res = append(res, NewArg(v.name, v.typ, v.variadic))
+	}
+	return res
+}
+
+// parseParamName get the name of param if it has, like `ctx` in `ctx context.Context`
+func (p *typeExprParser) parseParamName() string {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.src) && isParamNameChar(p.src[end]) {
+		end++
+	}
+	name := p.src[p.pos:end]
+	if name == "" || end == len(p.src) || (p.src[end] != ' ' && p.src[end] != '\t') {
+		return ""
+	}
+	switch name {
+	case "map", "chan", "func", "interface", "struct":
+		return ""
+	}
+	pos := p.pos
+	p.pos = end
+	if !p.canStartType() {
+		p.pos = pos
+		return ""
+	}
+	return name
+}
+
+func isParamNameChar(c byte) bool {
+	return c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isParamName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isParamNameChar(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isTypeNameChar the char of qualified type name, like `github.com/go-kit/kit/log.Logger`
+func isTypeNameChar(c byte) bool {
+	return isParamNameChar(c) || c == '.' || c == '/' || c == '-'
+}
@@ -0,0 +1,179 @@
+package gocoder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewTypeDetail(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     string
+		src     string
+		want    string
+		kind    reflect.Kind
+		imports []string
+	}{
+		{
+			name: "predeclared",
+			pkg:  "github.com/acme/y",
+			src:  "byte",
+			want: "byte",
+			kind: reflect.Uint8,
+		},
+		{
+			name:    "unqualified in pkg",
+			pkg:     "github.com/acme/y",
+			src:     "*Foo",
+			want:    "*y.Foo",
+			kind:    reflect.Ptr,
+			imports: []string{"github.com/acme/y"},
+		},
+		{
+			name:    "map of qualified",
+			src:     "map[string][]*github.com/acme/x.Foo",
+			want:    "map[string][]*x.Foo",
+			kind:    reflect.Map,
+			imports: []string{"github.com/acme/x"},
+		},
+		{
+			name:    "qualified map key",
+			src:     "map[time.Time]int",
+			want:    "map[time.Time]int",
+			kind:    reflect.Map,
+			imports: []string{"time"},
+		},
+		{
+			name: "array",
+			src:  "[4]byte",
+			want: "[4]byte",
+			kind: reflect.Array,
+		},
+		{
+			name: "send chan",
+			src:  "chan<- error",
+			want: "chan<- error",
+			kind: reflect.Chan,
+		},
+		{
+			name: "recv chan of chan",
+			src:  "<-chan chan int",
+			want: "<-chan chan int",
+			kind: reflect.Chan,
+		},
+		{
+			name: "chan of recv chan",
+			src:  "chan (<-chan int)",
+			want: "chan (<-chan int)",
+			kind: reflect.Chan,
+		},
+		{
+			name:    "chan of recv chan of qualified",
+			src:     "chan (<-chan github.com/acme/x.Foo)",
+			want:    "chan (<-chan x.Foo)",
+			kind:    reflect.Chan,
+			imports: []string{"github.com/acme/x"},
+		},
+		{
+			name: "func",
+			src:  "func() error",
+			want: "func() error",
+			kind: reflect.Func,
+		},
+		{
+			name:    "func with params",
+			src:     "func(ctx context.Context, a, b int, opts ...gopkg.in/yaml.v3.Node) (n int, err error)",
+			want:    "func(ctx context.Context, a int, b int, opts ...yaml_v3.Node) (n int, err error)",
+			kind:    reflect.Func,
+			imports: []string{"context", "gopkg.in/yaml.v3"},
+		},
+		{
+			name: "func param of func",
+			src:  "func(func() error, [2]string) func(int)",
+			want: "func(func() error, [2]string) func(int)",
+			kind: reflect.Func,
+		},
+		{
+			name:    "generic",
+			src:     "github.com/acme/x.Set[github.com/acme/x.Foo, []int]",
+			want:    "x.Set[x.Foo, []int]",
+			imports: []string{"github.com/acme/x"},
+		},
+		{
+			name: "empty interface and struct",
+			src:  "map[interface{}]struct{}",
+			want: "map[interface{}]struct{}",
+			kind: reflect.Map,
+		},
+		{
+			name:    "struct body",
+			src:     "struct{ A, B int; Base; *github.com/acme/x.Meta `json:\"meta\"`\n C []string \"json:\\\"c\\\"\" }",
+			want:    "struct{ A int; B int; Base; *x.Meta `json:\"meta\"`; C []string `json:\"c\"` }",
+			kind:    reflect.Struct,
+			imports: []string{"github.com/acme/x"},
+		},
+		{
+			name:    "interface body",
+			src:     "interface{ github.com/acme/x.Reader; Close() error; Read(p []byte) (n int, err error) }",
+			want:    "interface{ x.Reader; Close() error; Read(p []byte) (n int, err error) }",
+			kind:    reflect.Interface,
+			imports: []string{"github.com/acme/x"},
+		},
+		{
+			name: "type set",
+			src:  "interface{ ~int | ~string; String() string }",
+			want: "interface{ ~int | ~string; String() string }",
+			kind: reflect.Interface,
+		},
+		{
+			name:    "go-kit path",
+			src:     "[]github.com/go-kit/kit/log.Logger",
+			want:    "[]log.Logger",
+			kind:    reflect.Slice,
+			imports: []string{"github.com/go-kit/kit/log"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgTool := NewDefaultPkgTool()
+			typ := NewTypeDetail(tt.pkg, tt.src)
+			typ.SetInReference(true)
+			if got := ToCode(typ, NewToCodeOpt().PkgTool(pkgTool)); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+			if tt.kind != 0 && typ.Kind() != tt.kind {
+				t.Errorf("Kind() = %v, want %v", typ.Kind(), tt.kind)
+			}
+			for _, v := range tt.imports {
+				if _, ok := pkgTool.PkgAliasMap()[v]; !ok {
+					t.Errorf("PkgAliasMap() = %v, want %s imported", pkgTool.PkgAliasMap(), v)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTypeDetailElem(t *testing.T) {
+	typ := NewTypeDetail("", "map[string][3]*github.com/acme/x.Foo")
+	elem := typ.Elem()
+	if elem.Kind() != reflect.Array {
+		t.Fatalf("map Elem().Kind() = %v, want array", elem.Kind())
+	}
+	if got := elem.Elem().Elem().ShowString(); got != "github.com/acme/x.Foo" {
+		t.Errorf("array Elem().Elem() = %q, want github.com/acme/x.Foo", got)
+	}
+}
+
+func TestNewTypeDetailInvalid(t *testing.T) {
+	for _, src := range []string{"", "map[string", "[x]int", "func(a int, string)", "struct{ A int", "interface{ M() ", "*Foo)"} {
+		t.Run(src, func(t *testing.T) {
+			if _, err := parseTypeExpr("", src); err == nil {
+				t.Errorf("parseTypeExpr(%q) no error", src)
+			}
+			// the expression can't be parsed is kept as an opaque name
+			if got := NewTypeDetail("", src).String(); got != src {
+				t.Errorf("NewTypeDetail(%q).String() = %q", src, got)
+			}
+		})
+	}
+}
@@ -1,15 +1,10 @@
 package gocoder
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 )
 
-func useOfCastType(t Type, tool PkgTool) string {
-	return typeStringOut(t, tool, "")
-}
-
 func typeStrIter(refType reflect.Type, path string, tool PkgTool) string {
 	if refType == nil {
 		return ""
@@ -22,6 +17,8 @@ func typeStrIter(refType reflect.Type, path string, tool PkgTool) string {
 		return typeStrIter(refType.Elem(), path+"*", tool)
 	case reflect.Slice:
 		return typeStrIter(refType.Elem(), path+"[]", tool)
+	case reflect.Chan:
+		return path + chanElemString(chanDirPrefix(refType.ChanDir()), typeStrIter(refType.Elem(), "", tool))
 	case reflect.Map:
 		return typeStrIter(refType.Elem(), path+"map["+typeStrIter(refType.Key(), "", tool)+"]", tool)
 	default:
@@ -30,12 +27,115 @@ func typeStrIter(refType reflect.Type, path string, tool PkgTool) string {
 }
 
 func typeStringOut(t Type, tool PkgTool, toPkg string) string {
+	if terms := t.GetUnionTerms(); len(terms) > 0 {
+		strs := make([]string, len(terms))
+		for i, v := range terms {
+			strs[i] = typeFullStringOut(v, tool, toPkg)
+		}
+		return strings.Join(strs, " | ")
+	}
+	if tt, ok := t.(*tType); ok {
+		typeString := func(t Type) string {
+			return typeFullStringOut(t, tool, toPkg)
+		}
+		if tt.isSignature() {
+			return signatureString(tt.funcArgs, tt.funcReturns, typeString)
+		}
+		if str, ok := anonymousBodyStringOut(tt, typeString); ok {
+			return str
+		}
+		if tt.mapKey != nil {
+			return "map[" + typeString(tt.mapKey) + "]"
+		}
+		if rt := tt.Type; tt.Str == "" && rt != nil && rt.Kind() == reflect.Func && rt.Name() == "" {
+			args, returns := reflectSignatureArgs(rt)
+			return signatureString(args, returns, typeString)
+		}
+	}
+	str := typeNodeStringOut(t, tool, toPkg)
+	if args := t.GetTypeArgs(); len(args) > 0 {
+		strs := make([]string, len(args))
+		for i, v := range args {
+			strs[i] = typeFullStringOut(v, tool, toPkg)
+		}
+		str += "[" + strings.Join(strs, ", ") + "]"
+	}
+	return str
+}
+
+// reflectSignatureArgs get the args and returns of reflect func type
+func reflectSignatureArgs(rt reflect.Type) ([]Arg, []Arg) {
+	args := make([]Arg, rt.NumIn())
+	for i := range args {
+		if rt.IsVariadic() && i == rt.NumIn()-1 {
+			args[i] = NewArg("", NewType(rt.In(i).Elem()), true)
+		} else {
+			args[i] = NewArg("", NewType(rt.In(i)), false)
+		}
+	}
+	returns := make([]Arg, rt.NumOut())
+	for i := range returns {
+		returns[i] = NewArg("", NewType(rt.Out(i)), false)
+	}
+	return args, returns
+}
+
+// signatureString get the func signature string, like `func(ctx context.Context, vs ...int) (int, error)`
+func signatureString(args []Arg, returns []Arg, typeString func(Type) string) string {
+	argString := func(v Arg) string {
+		str := typeString(v.GetType())
+		if v.GetVariableLength() {
+			str = "..." + str
+		}
+		if v.GetName() != "" {
+			str = v.GetName() + " " + str
+		}
+		return str
+	}
+	strs := make([]string, len(args))
+	for i, v := range args {
+		strs[i] = argString(v)
+	}
+	res := "func(" + strings.Join(strs, ", ") + ")"
+	if len(returns) == 1 && returns[0].GetName() == "" {
+		return res + " " + argString(returns[0])
+	}
+	if len(returns) > 0 {
+		strs = make([]string, len(returns))
+		for i, v := range returns {
+			strs[i] = argString(v)
+		}
+		res += " (" + strings.Join(strs, ", ") + ")"
+	}
+	return res
+}
+
+// typeFullStringOut like typeStringOut, but include the whole type chain, like `*[]Foo`
+func typeFullStringOut(t Type, tool PkgTool, toPkg string) string {
+	str := typeStringOut(t, tool, toPkg)
+	if str == "" && t.GetNamed() != "" {
+		return t.GetNamed()
+	}
+	if isNamedWrapper(t) {
+		return str
+	}
+	if next := t.GetNext(); next != nil {
+		str = chanElemString(str, typeFullStringOut(next, tool, toPkg))
+	}
+	return str
+}
+
+func typeNodeStringOut(t Type, tool PkgTool, toPkg string) string {
 	str := t.CurrentCode()
 	if tool != nil {
 		if pkg := t.Package(); pkg != "" && pkg != toPkg {
 			if str == "" {
 				return ""
 			}
+			if rt := t.RefType(); t.GetRowStr() == "" && rt != nil && rt.Name() != "" && rt.PkgPath() == pkg {
+				// like `time.Time`, the reflect type string already has the package name
+				str = rt.Name()
+			}
 			prefix := ""
 			if strings.HasPrefix(str, "[]") {
 				str = str[2:]
@@ -60,35 +160,84 @@ func typeStringOut(t Type, tool PkgTool, toPkg string) string {
 	return str
 }
 
-func getZeroValueCode(t Type, tool PkgTool) string {
-	originT := t
-	getPtr := ""
-	if t == nil || t.RefType() == nil {
-		return `nil`
+// getZeroValueCode get the zero value code by the underlying kind, like `Foo{}`, `""`, `0`, `false` or `nil`,
+// the pointer of struct is a new value, like `&Foo{}`
+func getZeroValueCode(t Type, tool PkgTool, toPkg string) string {
+	if t == nil {
+		return "nil"
 	}
-	if t.Kind() == reflect.Ptr {
-		getPtr = "&"
-		t = t.Elem()
-		if t.Kind() != reflect.Struct {
-			return `nil`
-		}
+	switch k := t.Kind(); {
+	case k == reflect.Bool:
+		return "false"
+	case isNumericKind(k):
+		return "0"
+	case k == reflect.String:
+		return `""`
+	case k == reflect.Struct || k == reflect.Array:
+		return typeFullStringOut(t, tool, toPkg) + "{}"
+	case k == reflect.Slice || k == reflect.Map:
+		return "(" + typeFullStringOut(t, tool, toPkg) + ")(nil)"
+	case isNewValueZero(t):
+		return "&" + typeFullStringOut(t.Elem(), tool, toPkg) + "{}"
+	}
+	return "nil"
+}
+
+// isNewValueZero the zero value of type is a pointer to new value, like `&Foo{}`
+func isNewValueZero(t Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
+// isNamedWrapper the type is built by WarpNamed or WarpAlias, the Next is the underlying type in declaration
+func isNamedWrapper(t Type) bool {
+	tt, ok := t.(*tType)
+	return ok && tt.isNamedWrapper()
+}
+
+// isEmbeddedInterface the embedded element of interface is an interface, not a type set element like `~int | ~string`
+func isEmbeddedInterface(t Type) bool {
+	return len(t.GetUnionTerms()) == 0 && t.GetRowStr() != "~" && t.Kind() == reflect.Interface
+}
+
+// isUnresolvedType the symbolic type only known by name, like `io.Reader` not found by the decoder
+func isUnresolvedType(t Type) bool {
+	tt, ok := t.(*tType)
+	return ok && tt.Type == nil && tt.kind == 0 && tt.Str != "" && tt.Next == nil && tt.mapKey == nil
+}
+
+// anonymousBodyStringOut the anonymous struct or interface, like `struct{ A int }` in `[]struct{ A int }`
+func anonymousBodyStringOut(t *tType, typeString func(Type) string) (string, bool) {
+	if t.Type != nil || t.Named != "" || t.Str != "" || t.Next != nil {
+		return "", false
 	}
-	switch t.Kind() {
+	var head string
+	var strs []string
+	switch t.kind {
 	case reflect.Struct:
-		return getPtr + useOfCastType(t, tool) + "{}"
-	case reflect.Slice, reflect.Map:
-		if getPtr != "" {
-			return "(*" + useOfCastType(t, tool) + ")" + "(nil)"
+		head = "struct"
+		for _, v := range t.fields {
+			str := typeString(v.GetType())
+			if !v.IsEmbedded() {
+				str = v.GetName() + " " + str
+			}
+			if v.GetTag() != "" {
+				str += " `" + v.GetTag() + "`"
+			}
+			strs = append(strs, str)
 		}
-		return "(" + useOfCastType(t, tool) + ")" + "(nil)"
-	default:
-		zero := fmt.Sprint(reflect.Zero(t.RefType()).Interface())
-		if t.Kind() == reflect.String {
-			zero = `""`
+	case reflect.Interface:
+		head = "interface"
+		for _, v := range t.embeds {
+			strs = append(strs, typeString(v))
 		}
-		if getPtr != "" {
-			return "func() " + useOfCastType(originT, tool) + " { v := (" + useOfCastType(t, tool) + ")(" + zero + "); return &v }()"
+		for _, v := range t.funcs {
+			strs = append(strs, v.GetName()+strings.TrimPrefix(signatureString(v.GetArgs(), v.GetReturns(), typeString), "func"))
 		}
-		return zero
+	default:
+		return "", false
+	}
+	if len(strs) == 0 {
+		return head + "{}", true
 	}
+	return head + "{ " + strings.Join(strs, "; ") + " }", true
 }
@@ -34,6 +34,7 @@ type Value interface {
 	IsPtr() bool
 	Depth() int
 	NeedParent() bool
+	Precedence() int
 	Returns() []Value
 	Cast(interface{}) Value
 	Assertion(interface{}) Value
@@ -57,6 +58,32 @@ type Value interface {
 	And(interface{}) Value
 	UnPtr() Value
 	TakePtr() Value
+	Recv() Value
+	Send(interface{}) Value
+	Mod(interface{}) Value
+	BitAnd(interface{}) Value
+	BitOr(interface{}) Value
+	BitXor(interface{}) Value
+	AndNot(interface{}) Value
+	Shl(interface{}) Value
+	Shr(interface{}) Value
+	Neg() Value
+	BitNot() Value
+	Inc() Value
+	Dec() Value
+	AddSet(interface{}) Value
+	SubSet(interface{}) Value
+	MulSet(interface{}) Value
+	DivSet(interface{}) Value
+	ModSet(interface{}) Value
+	BitAndSet(interface{}) Value
+	BitOrSet(interface{}) Value
+	BitXorSet(interface{}) Value
+	AndNotSet(interface{}) Value
+	ShlSet(interface{}) Value
+	ShrSet(interface{}) Value
+	Slice(low, high interface{}) Value
+	Slice3(low, high, max interface{}) Value
 }
 
 // ValueAction type
@@ -88,32 +115,121 @@ const (
 	ValueActionFuncCall      ValueAction = "()"
 	ValueActionIndex         ValueAction = "[]"
 	ValueActionZero          ValueAction = "0"
+	ValueActionSend          ValueAction = "<-"
+	ValueActionRecv          ValueAction = "<-()"
+	ValueActionLiteral       ValueAction = "{}"
+	ValueActionKeyValue      ValueAction = ":"
+	ValueActionEncode        ValueAction = "lit"
+	ValueActionMod           ValueAction = "%"
+	ValueActionBitAnd        ValueAction = "&b"
+	ValueActionBitOr         ValueAction = "|"
+	ValueActionBitXor        ValueAction = "^"
+	ValueActionAndNot        ValueAction = "&^"
+	ValueActionShl           ValueAction = "<<"
+	ValueActionShr           ValueAction = ">>"
+	ValueActionNeg           ValueAction = "-()"
+	ValueActionBitNot        ValueAction = "^()"
+	ValueActionInc           ValueAction = "++"
+	ValueActionDec           ValueAction = "--"
+	ValueActionAddSet        ValueAction = "+="
+	ValueActionSubSet        ValueAction = "-="
+	ValueActionMulSet        ValueAction = "*="
+	ValueActionDivSet        ValueAction = "/="
+	ValueActionModSet        ValueAction = "%="
+	ValueActionBitAndSet     ValueAction = "&="
+	ValueActionBitOrSet      ValueAction = "|="
+	ValueActionBitXorSet     ValueAction = "^="
+	ValueActionAndNotSet     ValueAction = "&^="
+	ValueActionShlSet        ValueAction = "<<="
+	ValueActionShrSet        ValueAction = ">>="
+	ValueActionSlice         ValueAction = "[:]"
 )
 
-var actionCodeConv = map[ValueAction]string{
-	ValueActionUnPtr: "*",
+// precedence of value expression, the higher binds tighter, see https://go.dev/ref/spec#Operator_precedence
+const (
+	precedenceStatement = 0 // like `a = b`, `i++`, can't be used as operand
+	precedenceOrOr      = 1
+	precedenceAndAnd    = 2
+	precedenceCompare   = 3
+	precedenceAdd       = 4
+	precedenceMul       = 5
+	precedenceUnary     = 6
+	precedencePrimary   = 7 // like `a`, `a.b`, `a[i]`, `f()`
+)
+
+type valueOperator struct {
+	Token      string
+	Precedence int
+	Unary      bool
 }
 
-var valueActionNeedParent = map[ValueAction]bool{
-	ValueActionUnPtr: true,
-	ValueActionAdd:   true,
-	ValueActionSub:   true,
-	ValueActionMul:   true,
-	ValueActionDiv:   true,
-	// ValueActionEqual:   true,
-	// ValueActionGT:      true,
-	// ValueActionLT:      true,
-	// ValueActionGE:      true,
-	// ValueActionLE:      true,
-	// ValueActionNE:      true,
-	ValueActionOr:      true,
-	ValueActionAnd:     true,
-	ValueActionNot:     true,
-	ValueActionTakePtr: true,
+var valueOperators = map[ValueAction]valueOperator{
+	ValueActionSet:       {Token: "=", Precedence: precedenceStatement, Unary: false},
+	ValueActionAutoSet:   {Token: ":=", Precedence: precedenceStatement, Unary: false},
+	ValueActionAddSet:    {Token: "+=", Precedence: precedenceStatement, Unary: false},
+	ValueActionSubSet:    {Token: "-=", Precedence: precedenceStatement, Unary: false},
+	ValueActionMulSet:    {Token: "*=", Precedence: precedenceStatement, Unary: false},
+	ValueActionDivSet:    {Token: "/=", Precedence: precedenceStatement, Unary: false},
+	ValueActionModSet:    {Token: "%=", Precedence: precedenceStatement, Unary: false},
+	ValueActionBitAndSet: {Token: "&=", Precedence: precedenceStatement, Unary: false},
+	ValueActionBitOrSet:  {Token: "|=", Precedence: precedenceStatement, Unary: false},
+	ValueActionBitXorSet: {Token: "^=", Precedence: precedenceStatement, Unary: false},
+	ValueActionAndNotSet: {Token: "&^=", Precedence: precedenceStatement, Unary: false},
+	ValueActionShlSet:    {Token: "<<=", Precedence: precedenceStatement, Unary: false},
+	ValueActionShrSet:    {Token: ">>=", Precedence: precedenceStatement, Unary: false},
+	ValueActionInc:       {Token: "++", Precedence: precedenceStatement, Unary: false},
+	ValueActionDec:       {Token: "--", Precedence: precedenceStatement, Unary: false},
+	ValueActionSend:      {Token: "<-", Precedence: precedenceStatement, Unary: false},
+	ValueActionOr:        {Token: "||", Precedence: precedenceOrOr, Unary: false},
+	ValueActionAnd:       {Token: "&&", Precedence: precedenceAndAnd, Unary: false},
+	ValueActionEqual:     {Token: "==", Precedence: precedenceCompare, Unary: false},
+	ValueActionNE:        {Token: "!=", Precedence: precedenceCompare, Unary: false},
+	ValueActionGT:        {Token: ">", Precedence: precedenceCompare, Unary: false},
+	ValueActionLT:        {Token: "<", Precedence: precedenceCompare, Unary: false},
+	ValueActionGE:        {Token: ">=", Precedence: precedenceCompare, Unary: false},
+	ValueActionLE:        {Token: "<=", Precedence: precedenceCompare, Unary: false},
+	ValueActionAdd:       {Token: "+", Precedence: precedenceAdd, Unary: false},
+	ValueActionSub:       {Token: "-", Precedence: precedenceAdd, Unary: false},
+	ValueActionBitOr:     {Token: "|", Precedence: precedenceAdd, Unary: false},
+	ValueActionBitXor:    {Token: "^", Precedence: precedenceAdd, Unary: false},
+	ValueActionMul:       {Token: "*", Precedence: precedenceMul, Unary: false},
+	ValueActionDiv:       {Token: "/", Precedence: precedenceMul, Unary: false},
+	ValueActionMod:       {Token: "%", Precedence: precedenceMul, Unary: false},
+	ValueActionShl:       {Token: "<<", Precedence: precedenceMul, Unary: false},
+	ValueActionShr:       {Token: ">>", Precedence: precedenceMul, Unary: false},
+	ValueActionBitAnd:    {Token: "&", Precedence: precedenceMul, Unary: false},
+	ValueActionAndNot:    {Token: "&^", Precedence: precedenceMul, Unary: false},
+	ValueActionNot:       {Token: "!", Precedence: precedenceUnary, Unary: true},
+	ValueActionNeg:       {Token: "-", Precedence: precedenceUnary, Unary: true},
+	ValueActionBitNot:    {Token: "^", Precedence: precedenceUnary, Unary: true},
+	ValueActionUnPtr:     {Token: "*", Precedence: precedenceUnary, Unary: true},
+	ValueActionTakePtr:   {Token: "&", Precedence: precedenceUnary, Unary: true},
+	ValueActionRecv:      {Token: "<-", Precedence: precedenceUnary, Unary: true},
+}
+
+// unaryNeedParent the operand of unary operator need parentheses, like `-(a + b)`, or the first token of rendered
+// operand forms another token with the operator, like `-(-1)` rather than `--1`, `&(&^a)` rather than `&&^a`
+func unaryNeedParent(op valueOperator, v Value) bool {
+	if v.Precedence() < precedenceUnary {
+		return true
+	}
+	str := op.Token + ToCode(v)
+	for _, tok := range []string{"--", "++", "&&", "&^", "^^"} {
+		if strings.HasPrefix(str, tok) {
+			return true
+		}
+	}
+	return false
 }
 
-var valueActionForceNeedParent = map[ValueAction]bool{
-	ValueActionUnPtr: true,
+// binaryNeedParent the operand of binary operator need parentheses, binary operators are left-associative
+func binaryNeedParent(op valueOperator, c Codable, isRight bool) bool {
+	v, ok := c.(Value)
+	if !ok || op.Precedence == precedenceStatement {
+		return false
+	}
+	p := v.Precedence()
+	return p < op.Precedence || (isRight && p == op.Precedence)
 }
 
 type tValue struct {
@@ -242,12 +358,26 @@ func (t *tValue) Method(name string) Value {
 		// if noPtrT.Kind() == reflect.Ptr {
 		// 	noPtrT = noPtrT.Elem()
 		// }
-		if noPtrT.RefType() == nil {
+		if noPtrT.IsNil() {
+			// symbolic type, the method is typed only if the func is known
+			f := noPtrT.FuncByName(name)
+			if f == nil && noPtrT.Kind() == reflect.Ptr {
+				f = noPtrT.Elem().FuncByName(name)
+			}
+			var typ Type
+			var inTypes, outTypes []Type
+			if f != nil {
+				typ = f.Signature()
+				for _, v := range f.GetArgs() {
+					inTypes = append(inTypes, v.GetType())
+				}
+				outTypes = f.GetReturnTypes()
+			}
 			return &tValue{
 				TNoteCode:    TNoteCode{nil},
 				Left:         t,
 				Name:         name,
-				IType:        nil,
+				IType:        typ,
 				Action:       "",
 				Right:        nil,
 				IValue:       nil,
@@ -255,8 +385,8 @@ func (t *tValue) Method(name string) Value {
 				Func:         nil,
 				Values:       nil,
 				CallArgs:     nil,
-				CallArgTypes: nil,
-				CallReturns:  nil,
+				CallArgTypes: inTypes,
+				CallReturns:  outTypes,
 			}
 		}
 		if f, ok := noPtrT.MethodByName(name); !ok {
@@ -433,6 +563,10 @@ func (t *tValue) Assertion(i interface{}) Value {
 }
 
 func (t *tValue) Call(argsI ...interface{}) Value {
+	if t.Action == ValueActionNone && t.Func != nil && t.Name == "" {
+		// call the closure immediately, like `func() {}()`
+		return t.Func.Call(argsI...)
+	}
 	args := MustToValueList(argsI...)
 	if t.CallArgTypes != nil {
 		if len(t.CallArgTypes) > 0 && t.CallArgTypes[len(t.CallArgTypes)-1].Kind() == reflect.Slice {
@@ -547,21 +681,31 @@ func (t *tValue) Depth() int {
 	return i + max
 }
 
+// NeedParent the value need parentheses when it's the operand of primary expression, like `(a + b).String()`
 func (t *tValue) NeedParent() bool {
-	if valueActionForceNeedParent[t.Action] {
-		return true
-	}
-	if !valueActionNeedParent[t.Action] {
-		return false
-	}
-	depth := t.Depth()
-	if depth <= 1 {
-		return false
+	return t.Precedence() < precedencePrimary
+}
+
+// Precedence the operator precedence of value expression, the higher binds tighter
+func (t *tValue) Precedence() int {
+	switch t.Action {
+	case ValueActionEncode:
+		return literalPrecedence(t.IValue)
+	case ValueActionZero:
+		// like `&Foo{}`
+		if t.Type() != nil && isNewValueZero(t.Type()) {
+			return precedenceUnary
+		}
+	case ValueActionLiteral:
+		// like `&Foo{}`
+		if t.Type() != nil && t.Type().Kind() == reflect.Ptr {
+			return precedenceUnary
+		}
 	}
-	if t.Left == nil && depth == 2 {
-		return false
+	if op, ok := valueOperators[t.Action]; ok {
+		return op.Precedence
 	}
-	return true
+	return precedencePrimary
 }
 
 func (t *tValue) Set(i interface{}, opts ...*SetOption) Value {
@@ -666,7 +810,7 @@ func (t *tValue) Check() Value {
 
 func (t *tValue) Returns() []Value {
 	res := t.Values
-	if t.Func != nil {
+	if t.Func != nil || t.Action == ValueActionFuncCall {
 		res = append(res, t.GetReturns()...)
 	}
 	return res
@@ -977,3 +1121,252 @@ func (t *tValue) ToNoPtr() Value {
 	res.IType = res.Type().UnPtr()
 	return res
 }
+
+// Recv func, like `<-ch`
+func (t *tValue) Recv() Value {
+	var typ Type
+	if t.Type() != nil && t.Type().IsChan() {
+		typ = t.Type().Elem()
+	}
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Action:       ValueActionRecv,
+		Right:        t,
+		IType:        typ,
+		Name:         "",
+		Left:         nil,
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+// Send func, like `ch <- v`
+func (t *tValue) Send(i interface{}) Value {
+	v := MustToValue("", i)
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Left:         t,
+		Action:       ValueActionSend,
+		Right:        v,
+		IType:        nil,
+		Name:         "",
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+func (t *tValue) binary(action ValueAction, i interface{}) Value {
+	v := MustToValue("", i)
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Left:         t,
+		Action:       action,
+		Right:        v,
+		IType:        nil,
+		Name:         "",
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+// Mod func, like `a % b`
+func (t *tValue) Mod(i interface{}) Value {
+	return t.binary(ValueActionMod, i)
+}
+
+// BitAnd func, like `a & b`
+func (t *tValue) BitAnd(i interface{}) Value {
+	return t.binary(ValueActionBitAnd, i)
+}
+
+// BitOr func, like `a | b`
+func (t *tValue) BitOr(i interface{}) Value {
+	return t.binary(ValueActionBitOr, i)
+}
+
+// BitXor func, like `a ^ b`
+func (t *tValue) BitXor(i interface{}) Value {
+	return t.binary(ValueActionBitXor, i)
+}
+
+// AndNot func, like `a &^ b`
+func (t *tValue) AndNot(i interface{}) Value {
+	return t.binary(ValueActionAndNot, i)
+}
+
+// Shl func, like `a << b`
+func (t *tValue) Shl(i interface{}) Value {
+	return t.binary(ValueActionShl, i)
+}
+
+// Shr func, like `a >> b`
+func (t *tValue) Shr(i interface{}) Value {
+	return t.binary(ValueActionShr, i)
+}
+
+func (t *tValue) unary(action ValueAction) Value {
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Action:       action,
+		Right:        t,
+		IType:        nil,
+		Name:         "",
+		Left:         nil,
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+// Neg func, like `-a`
+func (t *tValue) Neg() Value {
+	return t.unary(ValueActionNeg)
+}
+
+// BitNot func, like `^a`
+func (t *tValue) BitNot() Value {
+	return t.unary(ValueActionBitNot)
+}
+
+func (t *tValue) statement(action ValueAction, right Value) Value {
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Left:         t,
+		Action:       action,
+		Right:        right,
+		IType:        t.Type(),
+		Name:         "",
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		Values:       nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
+
+// Inc func, like `i++`
+func (t *tValue) Inc() Value {
+	return t.statement(ValueActionInc, nil)
+}
+
+// Dec func, like `i--`
+func (t *tValue) Dec() Value {
+	return t.statement(ValueActionDec, nil)
+}
+
+// AddSet func, like `a += b`
+func (t *tValue) AddSet(i interface{}) Value {
+	return t.statement(ValueActionAddSet, MustToValue("", i))
+}
+
+// SubSet func, like `a -= b`
+func (t *tValue) SubSet(i interface{}) Value {
+	return t.statement(ValueActionSubSet, MustToValue("", i))
+}
+
+// MulSet func, like `a *= b`
+func (t *tValue) MulSet(i interface{}) Value {
+	return t.statement(ValueActionMulSet, MustToValue("", i))
+}
+
+// DivSet func, like `a /= b`
+func (t *tValue) DivSet(i interface{}) Value {
+	return t.statement(ValueActionDivSet, MustToValue("", i))
+}
+
+// ModSet func, like `a %= b`
+func (t *tValue) ModSet(i interface{}) Value {
+	return t.statement(ValueActionModSet, MustToValue("", i))
+}
+
+// BitAndSet func, like `a &= b`
+func (t *tValue) BitAndSet(i interface{}) Value {
+	return t.statement(ValueActionBitAndSet, MustToValue("", i))
+}
+
+// BitOrSet func, like `a |= b`
+func (t *tValue) BitOrSet(i interface{}) Value {
+	return t.statement(ValueActionBitOrSet, MustToValue("", i))
+}
+
+// BitXorSet func, like `a ^= b`
+func (t *tValue) BitXorSet(i interface{}) Value {
+	return t.statement(ValueActionBitXorSet, MustToValue("", i))
+}
+
+// AndNotSet func, like `a &^= b`
+func (t *tValue) AndNotSet(i interface{}) Value {
+	return t.statement(ValueActionAndNotSet, MustToValue("", i))
+}
+
+// ShlSet func, like `a <<= b`
+func (t *tValue) ShlSet(i interface{}) Value {
+	return t.statement(ValueActionShlSet, MustToValue("", i))
+}
+
+// ShrSet func, like `a >>= b`
+func (t *tValue) ShrSet(i interface{}) Value {
+	return t.statement(ValueActionShrSet, MustToValue("", i))
+}
+
+// Slice func, like `a[low:high]`, nil index will be omitted
+func (t *tValue) Slice(low, high interface{}) Value {
+	return t.slice(low, high)
+}
+
+// Slice3 func, like `a[low:high:max]`
+func (t *tValue) Slice3(low, high, max interface{}) Value {
+	if high == nil || max == nil {
+		panic("Slice3 high and max index can't be nil")
+	}
+	return t.slice(low, high, max)
+}
+
+func (t *tValue) slice(indices ...interface{}) Value {
+	vs := make([]Value, len(indices))
+	for i, v := range indices {
+		if v != nil {
+			vs[i] = MustToValue("", v)
+		}
+	}
+	typ := t.Type()
+	if typ != nil && typ.RefType() != nil && typ.Kind() == reflect.Array {
+		typ = NewType(reflect.SliceOf(typ.RefType().Elem()))
+	}
+	return &tValue{
+		TNoteCode:    TNoteCode{nil},
+		Left:         t,
+		Action:       ValueActionSlice,
+		Values:       vs,
+		IType:        typ,
+		Name:         "",
+		Right:        nil,
+		IValue:       nil,
+		Str:          "",
+		Func:         nil,
+		CallArgs:     nil,
+		CallArgTypes: nil,
+		CallReturns:  nil,
+	}
+}
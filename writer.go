@@ -37,6 +37,7 @@ type Writer interface {
 	ParenthesesValues(vs ...Value)
 	ParenthesesArgs(vs ...Arg)
 	ParenthesesTypes(vs ...Type)
+	BracketsArgs(vs ...Arg)
 	Block(is ...interface{})
 	BlockCodes(cs ...Codable)
 	InlineBlock(is ...interface{})
@@ -58,6 +59,8 @@ type tWriter struct {
 	inline     bool
 	pkgTool    PkgTool
 	toPkg      string
+	methods    bool // write the methods after the type declaration
+	expand     bool // write the full method list of interface instead of the embedded interfaces
 }
 
 // ToCode func
@@ -78,6 +81,8 @@ func ToCode(c Codable, opts ...*ToCodeOption) string {
 		out:        &bytes.Buffer{},
 		pkgTool:    pkgTool,
 		toPkg:      toPkg,
+		methods:    opt.methods != nil && *opt.methods,
+		expand:     opt.expand != nil && *opt.expand,
 		indent:     0,
 		notHead:    false,
 		needIndent: false,
@@ -278,6 +283,9 @@ func (w *tWriter) WriteCode(c Codable) {
 		typ := t.GetType().Clone()
 		typ.SetInReference(true)
 		is := []interface{}{t.GetName(), " ", typ}
+		if t.IsEmbedded() {
+			is = []interface{}{typ}
+		}
 		if t.GetTag() != "" {
 			is = append(is, " `"+t.GetTag()+"`")
 		}
@@ -291,16 +299,31 @@ func (w *tWriter) WriteCode(c Codable) {
 				w.AddStr(t.GetNamed() + " ")
 			} else {
 				nextType := t.GetNext()
-				if nextType != nil {
+				if nextType != nil && !isNamedWrapper(t) {
 					nextType = nextType.Clone()
 					nextType.SetInReference(t.InReference())
+					w.typeElemToCode(str, nextType)
+				} else {
+					w.Add(str)
 				}
-				w.Add(str, nextType)
 			}
+		} else if isNamedWrapper(t) {
+			// like `type Foo string` or `type Foo = string`
+			nextType := t.GetNext().Clone()
+			nextType.SetInReference(true)
+			w.Add("type ", t.GetNamed())
+			w.BracketsArgs(t.GetTypeParams()...)
+			if t.IsAlias() {
+				w.Add(" =")
+			}
+			w.Line(" ", nextType)
+			w.typeMethodsToCode(t)
 		} else {
 			switch t.Kind() {
 			case reflect.Struct:
-				w.Line("type ", t.Name(), " struct {")
+				w.Add("type ", t.Name())
+				w.BracketsArgs(t.GetTypeParams()...)
+				w.Line(" struct {")
 				fs := t.GetFields()
 				is := make([]interface{}, len(fs))
 				for i, v := range fs {
@@ -308,9 +331,23 @@ func (w *tWriter) WriteCode(c Codable) {
 				}
 				w.Add(is...)
 				w.Line("}")
+				w.typeMethodsToCode(t)
 			case reflect.Interface:
-				w.Line("type ", t.Name(), " interface {")
+				w.Add("type ", t.Name())
+				w.BracketsArgs(t.GetTypeParams()...)
+				w.Line(" interface {")
 				fs := t.GetFuncs()
+				for _, v := range t.GetEmbeds() {
+					if w.expand && isEmbeddedInterface(v) && !isUnresolvedType(v) {
+						continue
+					}
+					typ := v.Clone()
+					typ.SetInReference(true)
+					w.Line(typ)
+				}
+				if w.expand {
+					fs = t.ExpandFuncs()
+				}
 				for _, v := range fs {
 					w.InterfaceFuncToCode(v)
 				}
@@ -324,8 +361,10 @@ func (w *tWriter) WriteCode(c Codable) {
 					if nextType != nil {
 						nextType = nextType.Clone()
 						nextType.SetInReference(t.InReference())
+						w.typeElemToCode(str, nextType)
+					} else {
+						w.Add(str)
 					}
-					w.Add(str, nextType)
 				}
 			}
 		}
@@ -339,20 +378,49 @@ func (w *tWriter) WriteCode(c Codable) {
 		w.IfToCode(t)
 	case PtrChecker:
 		w.PtrCheckerToCode(t)
+	case Switch:
+		w.SwitchToCode(t)
+	case TypeSwitch:
+		w.TypeSwitchToCode(t)
+	case Select:
+		w.SelectToCode(t)
+	case Case:
+		w.CaseToCode(t)
 	case Arg:
 		typ := t.GetType().Clone()
 		typ.SetInReference(true)
+		if t.GetName() != "" {
+			w.Add(t.GetName(), " ")
+		}
 		if t.GetVariableLength() {
-			w.Add(t.GetName(), " ...", typ)
+			w.Add("...", typ)
 		} else {
-			w.Add(t.GetName(), " ", typ)
+			w.Add(typ)
 		}
 	case Func:
 		w.FuncToCode(t)
 	case ForRange:
 		w.ForRangeToCode(t)
+	case For:
+		w.ForToCode(t)
+	case Label:
+		w.Line(t.GetName(), ":")
+		w.Add(t.GetCode())
+	case Branch:
+		w.Add(string(t.GetKind()))
+		if t.GetLabel() != "" {
+			w.Add(" ", t.GetLabel())
+		}
 	case Return:
 		w.Add("return ", t.GetValue())
+	case Decl:
+		w.DeclToCode(t)
+	case Spec:
+		w.SpecToCode(t)
+	case Go:
+		w.Add("go ", t.GetValue())
+	case Defer:
+		w.Add("defer ", t.GetValue())
 	case Code:
 		w.CodeToCode(t)
 	default:
@@ -380,7 +448,7 @@ func (w *tWriter) ValueToCode(t Value) {
 		switch {
 		case t.GetName() != "":
 			if t.GetLeft() != nil {
-				w.Add(t.GetLeft())
+				w.operandToCode(t.GetLeft(), valueNeedParent(t.GetLeft()))
 				w.AddStr(".")
 			}
 			name := t.GetName()
@@ -402,87 +470,118 @@ func (w *tWriter) ValueToCode(t Value) {
 				}
 			}
 			w.Add(name)
+		case t.GetFunc() != nil:
+			w.WriteCode(t.GetFunc())
 		case t.GetSrcValue() != nil:
 			if str, ok := t.GetSrcValue().(string); ok {
 				w.Add(`"` + str + `"`)
+			} else if isCompositeValue(t.GetSrcValue()) {
+				w.Add(encodeLiteral(t.GetSrcValue(), w.pkgTool, w.toPkg))
 			} else {
 				w.Add(t.GetSrcValue())
 			}
 		case t.GetValues() != nil:
 			w.ListValues(t.GetValues()...)
 		case t.Type() != nil:
-			w.Add(t.Type())
+			typ := t.Type().Clone()
+			typ.SetInReference(true)
+			w.Add(typ)
 		default:
 			panic(fmt.Sprintf("unknown value: %+v", t))
 		}
 	case ValueActionZero:
-		w.Add(getZeroValueCode(t.Type(), w.pkgTool))
+		w.Add(getZeroValueCode(t.Type(), w.pkgTool, w.toPkg))
+	case ValueActionEncode:
+		w.Add(encodeLiteral(t.GetSrcValue(), w.pkgTool, w.toPkg))
+	case ValueActionLiteral:
+		typ := t.Type()
+		if typ.Kind() == reflect.Ptr {
+			w.AddStr("&")
+			typ = typ.Elem()
+		}
+		typ = typ.Clone()
+		typ.SetInReference(true)
+		w.Add(typ)
+		w.AddStr("{")
+		w.ListValues(t.GetValues()...)
+		w.AddStr("}")
+	case ValueActionKeyValue:
+		w.Add(t.GetLeft())
+		w.AddStr(": ")
+		w.Add(t.GetRight())
 	case ValueActionCastType:
 		w.Parentheses(t.GetLeft())
 		w.Parentheses(t.GetRight())
 	case ValueActionAssertionType:
-		w.Add(t.GetLeft())
+		w.operandToCode(t.GetLeft(), valueNeedParent(t.GetLeft()))
 		w.AddStr(".")
 		w.Parentheses(t.Type())
 	case ValueActionIndex:
-		if lv, ok := t.GetLeft().(Value); ok && lv.NeedParent() {
-			w.Parentheses(t.GetLeft())
-		} else {
-			w.Add(t.GetLeft())
-		}
+		w.operandToCode(t.GetLeft(), valueNeedParent(t.GetLeft()))
 		w.AddStr("[")
 		w.AddCompact(t.GetRight())
 		w.AddStr("]")
+	case ValueActionSlice:
+		w.operandToCode(t.GetLeft(), valueNeedParent(t.GetLeft()))
+		w.AddStr("[")
+		for i, v := range t.GetValues() {
+			if i != 0 {
+				w.AddStr(":")
+			}
+			w.AddCompact(v)
+		}
+		w.AddStr("]")
 	case ValueActionFuncCall:
 		if f := t.GetFunc(); f != nil {
 			w.WriteCode(f)
 			w.ParenthesesValues(t.GetCallArgs()...)
 		} else {
-			w.Add(t.GetLeft())
+			w.operandToCode(t.GetLeft(), valueNeedParent(t.GetLeft()))
 			w.ParenthesesValues(t.GetCallArgs()...)
 		}
 	case ValueActionDot:
-		w.Add(t.GetLeft())
+		w.operandToCode(t.GetLeft(), valueNeedParent(t.GetLeft()))
 		w.AddStr(".")
 		w.Add(t.GetName())
 	default:
-		if t.GetLeft() != nil {
-			if lv, ok := t.GetLeft().(Value); ok && lv.NeedParent() {
-				w.Parentheses(t.GetLeft())
-			} else {
-				w.Add(t.GetLeft())
-			}
+		op, ok := valueOperators[t.GetAction()]
+		if !ok {
+			panic(fmt.Sprintf("unknown value action: %s", t.GetAction()))
 		}
-		if v, ok := actionCodeConv[t.GetAction()]; ok {
-			w.Add(v)
-		} else {
-			if t.GetLeft() != nil {
-				w.Add(" ")
-			}
-			w.Add(t.GetAction())
-			if t.GetLeft() != nil {
-				w.Add(" ")
-			}
-		}
-		if t.GetRight() != nil {
-			lv := t.GetRight()
-			if lv.NeedParent() || (t.GetAction() == ValueActionNot && lv.Depth() > 1) {
-				w.Parentheses(t.GetRight())
-			} else {
-				if t.GetLeft() == nil {
-					w.AddCompact(t.GetRight())
-				} else {
-					w.Add(t.GetRight())
-				}
-			}
+		switch {
+		case op.Unary:
+			w.AddStr(op.Token)
+			w.operandToCode(t.GetRight(), unaryNeedParent(op, t.GetRight()))
+		case t.GetRight() == nil:
+			// like `i++`
+			w.operandToCode(t.GetLeft(), binaryNeedParent(op, t.GetLeft(), false))
+			w.AddStr(op.Token)
+		default:
+			w.operandToCode(t.GetLeft(), binaryNeedParent(op, t.GetLeft(), false))
+			w.AddStr(" ", op.Token, " ")
+			w.operandToCode(t.GetRight(), binaryNeedParent(op, t.GetRight(), true))
 		}
 	}
 }
 
+func valueNeedParent(c Codable) bool {
+	v, ok := c.(Value)
+	return ok && v.NeedParent()
+}
+
+func (w *tWriter) operandToCode(c Codable, needParent bool) {
+	if needParent {
+		w.Parentheses(c)
+	} else {
+		w.Add(c)
+	}
+}
+
 func (w *tWriter) CodeToCode(t Code) {
 	for index, v := range t.GetCodes() {
 		if index != 0 {
-			if _, ok := v.(Func); ok {
+			switch v.(type) {
+			case Func, Decl:
 				w.Line()
 			}
 		}
@@ -493,6 +592,34 @@ func (w *tWriter) CodeToCode(t Code) {
 	}
 }
 
+func (w *tWriter) DeclToCode(t Decl) {
+	w.Add(string(t.GetKind()), " ")
+	if !t.IsGrouped() {
+		w.Add(t.GetSpecs()[0])
+		return
+	}
+	w.Line("(")
+	w.In()
+	for _, v := range t.GetSpecs() {
+		w.Line(v)
+	}
+	w.Out()
+	w.Add(")")
+}
+
+func (w *tWriter) SpecToCode(t Spec) {
+	w.Add(strings.Join(t.GetNames(), ", "))
+	if t.GetType() != nil {
+		typ := t.GetType().Clone()
+		typ.SetInReference(true)
+		w.Add(" ", typ)
+	}
+	if len(t.GetValues()) > 0 {
+		w.AddStr(" = ")
+		w.ListValues(t.GetValues()...)
+	}
+}
+
 func (w *tWriter) FuncToCode(t Func) {
 	if t.GetType() == FuncTypeInline {
 		oldInline := w.inline
@@ -509,8 +636,9 @@ func (w *tWriter) FuncToCode(t Func) {
 		w.AddStr(" ")
 		w.Add(t.GetName())
 	}
+	w.BracketsArgs(t.GetTypeParams()...)
 	w.ParenthesesArgs(t.GetArgs()...)
-	if len(t.GetReturns()) > 1 {
+	if len(t.GetReturns()) > 1 || (len(t.GetReturns()) == 1 && t.GetReturns()[0].GetName() != "") {
 		w.AddStr(" ")
 		w.ParenthesesArgs(t.GetReturns()...)
 	} else if len(t.GetReturns()) > 0 {
@@ -525,6 +653,27 @@ func (w *tWriter) FuncToCode(t Func) {
 	}
 }
 
+// typeElemToCode write the type node string and its element type, like `chan (<-chan int)`
+func (w *tWriter) typeElemToCode(str string, elem Type) {
+	if chanElemNeedParent(str, typeFullStringOut(elem, w.pkgTool, w.toPkg)) {
+		w.Add(str, "(", elem, ")")
+		return
+	}
+	w.Add(str, elem)
+}
+
+// typeMethodsToCode write the methods of the declared type if the methods option is enabled
+func (w *tWriter) typeMethodsToCode(t Type) {
+	if !w.methods {
+		return
+	}
+	for _, v := range t.GetMethods() {
+		w.Line()
+		w.Add(v)
+		w.Line()
+	}
+}
+
 func (w *tWriter) InterfaceFuncToCode(t Func) {
 	if t.GetType() == FuncTypeInline {
 		panic("inline func can't be interface method")
@@ -534,7 +683,7 @@ func (w *tWriter) InterfaceFuncToCode(t Func) {
 		w.Add(t.GetName())
 	}
 	w.ParenthesesArgs(t.GetArgs()...)
-	if len(t.GetReturns()) > 1 {
+	if len(t.GetReturns()) > 1 || (len(t.GetReturns()) == 1 && t.GetReturns()[0].GetName() != "") {
 		w.AddStr(" ")
 		w.ParenthesesArgs(t.GetReturns()...)
 	} else if len(t.GetReturns()) > 0 {
@@ -559,6 +708,65 @@ func (w *tWriter) IfToCode(t BaseIf) {
 	}
 }
 
+func (w *tWriter) SwitchToCode(t Switch) {
+	w.Add("switch ")
+	if t.GetInit() != nil {
+		w.Add(t.GetInit(), "; ")
+	}
+	if t.GetValue() != nil {
+		w.Add(t.GetValue(), " ")
+	}
+	w.casesToCode(t.GetCases())
+}
+
+func (w *tWriter) TypeSwitchToCode(t TypeSwitch) {
+	w.Add("switch ")
+	if t.GetBind() != nil {
+		w.Add(t.GetBind(), " := ")
+	}
+	if t.GetValue().NeedParent() {
+		w.Parentheses(t.GetValue())
+	} else {
+		w.Add(t.GetValue())
+	}
+	w.AddStr(".(type) ")
+	w.casesToCode(t.GetCases())
+}
+
+func (w *tWriter) SelectToCode(t Select) {
+	w.Add("select ")
+	w.casesToCode(t.GetCases())
+}
+
+func (w *tWriter) casesToCode(cases []Case) {
+	w.Line("{")
+	for _, c := range cases {
+		w.Add(c)
+	}
+	w.Add("}")
+}
+
+func (w *tWriter) CaseToCode(t Case) {
+	if t.IsDefault() {
+		w.Line("default:")
+	} else {
+		w.Add("case ")
+		w.ListValues(t.GetValues()...)
+		w.Line(":")
+	}
+	w.In()
+	for _, c := range t.GetCodes() {
+		w.Add(c)
+		if !w.IsHead() {
+			w.Line()
+		}
+	}
+	if t.GetFallthrough() {
+		w.Line("fallthrough")
+	}
+	w.Out()
+}
+
 func (w *tWriter) NoteToCode(t Note) {
 	if t.GetContent() != "" {
 		switch t.GetKind() {
@@ -612,11 +820,13 @@ func (w *tWriter) ForRangeToCode(t ForRange) {
 		}()
 	}
 	w.Add("for ")
-	w.Add(t.GetToValues())
-	if t.GetAutoSet() {
-		w.Add(" := ")
-	} else {
-		w.Add(" = ")
+	if t.GetToValues() != nil {
+		w.Add(t.GetToValues())
+		if t.GetAutoSet() {
+			w.Add(" := ")
+		} else {
+			w.Add(" = ")
+		}
 	}
 	w.Add("range ")
 	w.Add(t.GetValue().UnPtr())
@@ -628,6 +838,27 @@ func (w *tWriter) ForRangeToCode(t ForRange) {
 	}
 }
 
+func (w *tWriter) ForToCode(t For) {
+	if t.GetType() == FuncTypeInline {
+		oldInline := w.inline
+		w.inline = true
+		defer func() {
+			w.inline = oldInline
+		}()
+	}
+	w.Add("for ")
+	if t.GetInit() != nil || t.GetPost() != nil {
+		w.Add(t.GetInit(), "; ", t.GetCond(), "; ", t.GetPost(), " ")
+	} else if t.GetCond() != nil {
+		w.Add(t.GetCond(), " ")
+	}
+	if t.GetType() == FuncTypeInline {
+		w.InlineBlockCodes(t.GetCodes()...)
+	} else {
+		w.BlockCodes(t.GetCodes()...)
+	}
+}
+
 // IsHead func
 func (w *tWriter) IsHead() bool {
 	return !w.notHead
@@ -820,6 +1051,20 @@ func (w *tWriter) ParenthesesTypes(vs ...Type) {
 	w.Parentheses(is...)
 }
 
+// BracketsArgs func // [***], write nothing if vs is empty
+func (w *tWriter) BracketsArgs(vs ...Arg) {
+	if len(vs) == 0 {
+		return
+	}
+	is := make([]interface{}, len(vs))
+	for i, v := range vs {
+		is[i] = v
+	}
+	w.AddStr("[")
+	w.List(is...)
+	w.AddStr("]")
+}
+
 // AddStr func
 func (w *tWriter) AddStr(strs ...interface{}) {
 	noNil := make([]interface{}, 0, len(strs))
@@ -0,0 +1,54 @@
+package gocoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypeZeroToCode(t *testing.T) {
+	status := NewTypeI("").WarpNamed("Status")
+	status.SetPkg("github.com/acme/x")
+	tests := []struct {
+		name string
+		typ  Type
+		want string
+	}{
+		{name: "int", typ: NewTypeI(0), want: "0"},
+		{name: "float", typ: NewTypeI(1.5), want: "0"},
+		{name: "complex", typ: NewTypeI(complex(1, 2)), want: "0"},
+		{name: "duration", typ: NewTypeI(time.Second), want: "0"},
+		{name: "bool", typ: NewTypeI(true), want: "false"},
+		{name: "string", typ: NewTypeI(""), want: `""`},
+		{name: "error", typ: NewTypeName("error"), want: "nil"},
+		{name: "slice", typ: NewTypeI([]int{}), want: "([]int)(nil)"},
+		{name: "map", typ: NewTypeI(map[string]time.Time{}), want: "(map[string]time.Time)(nil)"},
+		{name: "struct", typ: NewTypeI(time.Time{}), want: "time.Time{}"},
+		{name: "ptr of struct", typ: NewTypeI(&time.Time{}), want: "&time.Time{}"},
+		{name: "ptr of int", typ: NewTypeI((*int)(nil)), want: "nil"},
+		{name: "ptr of string", typ: NewTypeI((*string)(nil)), want: "nil"},
+		{name: "symbolic int", typ: NewTypeName("uint16"), want: "0"},
+		{name: "symbolic array", typ: NewTypeName("[4]byte"), want: "[4]byte{}"},
+		{name: "symbolic map", typ: NewTypeDetail("github.com/acme/x", "map[string]Foo"), want: "(map[string]x.Foo)(nil)"},
+		{name: "symbolic chan", typ: NewTypeName("chan int"), want: "nil"},
+		{name: "symbolic func", typ: NewTypeName("func() error"), want: "nil"},
+		{name: "named string", typ: status, want: `""`},
+		{name: "named struct", typ: NewStruct("Foo", []Field{NewField("A", NewTypeI(0), "")}), want: "Foo{}"},
+		{name: "ptr of named struct", typ: NewStruct("Foo", nil).TackPtr(), want: "&Foo{}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCode(tt.typ.Zero()); got != tt.want {
+				t.Errorf("ToCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeZeroPrecedence(t *testing.T) {
+	if got, want := ToCode(NewTypeI(&time.Time{}).Zero().Method("Unix").Call()), "(&time.Time{}).Unix()"; got != want {
+		t.Errorf("ToCode() = %q, want %q", got, want)
+	}
+	if NewTypeI((*int)(nil)).Zero().NeedParent() {
+		t.Errorf("NeedParent() of nil = true, want false")
+	}
+}